@@ -0,0 +1,35 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package cmd
+
+import (
+	"time"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var checksOpts tui.ChecksOptions
+
+// checksCmd represents the checks command
+var checksCmd = &cobra.Command{
+	Use:   "checks",
+	Short: "Show CI checks for the current commit",
+	Long: `This command asks the hosting service (GitHub or GitLab) for the CI checks
+reported on HEAD and shows each one with its state and duration. With --wait it
+keeps polling until every check has finished, for at most --max-wait, and stops
+early when no checks show up for the commit at all. It exits 7 if any check failed
+and 8 if checks were still running when --max-wait ran out.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return tui.RunChecksTUI(cmd.Context(), checksOpts)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(checksCmd)
+
+	checksCmd.Flags().BoolVarP(&checksOpts.Wait, "wait", "w", false, "wait until all checks complete")
+	checksCmd.Flags().DurationVar(&checksOpts.Interval, "interval", 0, "how often to poll while waiting (default 10s)")
+	checksCmd.Flags().DurationVar(&checksOpts.MaxWait, "max-wait", 30*time.Minute, "give up waiting after this long; 0 waits until the checks finish")
+}
@@ -14,7 +14,7 @@ var cleanCmd = &cobra.Command{
 	Short: "Clean up commits interactively (squash/fixup)",
 	Long:  `This command will clean up commits interactively (squash/fixup).`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return tui.RunCleanTUI()
+		return tui.RunCleanTUI(cmd.Context())
 	},
 }
 
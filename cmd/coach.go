@@ -0,0 +1,36 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package cmd
+
+import (
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var coachOpts tui.CoachOptions
+
+// coachCmd represents the coach command
+var coachCmd = &cobra.Command{
+	Use:   "coach",
+	Short: "Get personalised tips from your recent Git habits",
+	Long: `This command looks at this repository's reflog, your recent commits and the
+stash for habits worth changing: force pushes without a lease, merge commits
+created by git pull, frequent resets, abandoned rebases, commits made straight
+on the trunk and fixup commits left for review. Each tip explains why it matters
+and names a tutor lesson to practise the alternative.
+
+Everything runs locally; nothing leaves your machine. Set coach.summary: true in
+config to get the top tip at the end of sync and clean.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return tui.RunCoach(cmd.Context(), coachOpts)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(coachCmd)
+
+	coachCmd.Flags().IntVar(&coachOpts.Days, "days", 0, "days of activity to analyse (default from config, 30)")
+	addJSONFlag(coachCmd)
+}
@@ -0,0 +1,35 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package cmd
+
+import (
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/tracker"
+	"github.com/spf13/cobra"
+)
+
+// hookCmd groups the entry points called from git hooks installed by GitMate
+var hookCmd = &cobra.Command{
+	Use:    "hook",
+	Short:  "Entry points for git hooks installed by GitMate",
+	Hidden: true,
+}
+
+// prepareCommitMsgCmd is run by the prepare-commit-msg hook
+var prepareCommitMsgCmd = &cobra.Command{
+	Use:   "prepare-commit-msg <msg-file> [source] [sha]",
+	Short: "Prefix the commit message with the branch's linked issue key",
+	Args:  cobra.RangeArgs(1, 3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		source := ""
+		if len(args) > 1 {
+			source = args[1]
+		}
+		return tracker.PrepareCommitMsg(cmd.Context(), ".", args[0], source)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(hookCmd)
+	hookCmd.AddCommand(prepareCommitMsgCmd)
+}
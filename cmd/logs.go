@@ -0,0 +1,35 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package cmd
+
+import (
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var logsOpts tui.LogsOptions
+
+// logsCmd represents the logs command
+var logsCmd = &cobra.Command{
+	Use:   "logs",
+	Short: "Print the log of recent GitMate runs for bug reports",
+	Long: `Every GitMate run is logged with a timestamp: the command line, each git
+command with its output, duration and exit code, and how the run ended.
+Credentials in URLs are masked. Attach the output to bug reports.
+
+The log lives in $XDG_STATE_HOME/gitmate (~/.local/state/gitmate by default;
+the user config directory on macOS and Windows) and is rotated at 1 MiB,
+keeping the three previous files.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return tui.RunLogs(logsOpts)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(logsCmd)
+
+	logsCmd.Flags().IntVarP(&logsOpts.Sessions, "sessions", "n", 5, "number of recent runs to print; 0 for all")
+	logsCmd.Flags().BoolVar(&logsOpts.Path, "path", false, "print where the log is kept")
+}
@@ -0,0 +1,47 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package cmd
+
+import (
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	prOpts  tui.PROptions
+	prDraft bool
+)
+
+// prCmd represents the pr command
+var prCmd = &cobra.Command{
+	Use:   "pr",
+	Short: "Create or update the pull/merge request for the current branch",
+	Long: `This command opens (or updates) the GitHub pull request or GitLab merge request
+for the current branch, prefilling the title and body from your commits. The
+service is detected from the remote URL (override with hosting: in .gitmate.yml).
+Tokens are read from GITHUB_TOKEN/GH_TOKEN or GITLAB_TOKEN, the git credential
+helper, or github.token/gitlab.token in config; github.apiURL and gitlab.apiURL
+point it at self-hosted instances.
+
+Unless you pass --reviewer or --no-suggest, new pull requests are prefilled with
+the top reviewers from ` + "`gitmate reviewers`" + `.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// only a given --draft changes the state of an existing pull request
+		if cmd.Flags().Changed("draft") {
+			prOpts.Draft = &prDraft
+		}
+		return tui.RunPRTUI(cmd.Context(), prOpts)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(prCmd)
+
+	prCmd.Flags().StringVar(&prOpts.Base, "base", "", "branch to merge into (default: trunk)")
+	prCmd.Flags().BoolVar(&prDraft, "draft", false, "open the pull request as a draft; --draft=false marks an existing one ready for review")
+	prCmd.Flags().StringSliceVarP(&prOpts.Reviewers, "reviewer", "r", nil, "request a review from these users")
+	prCmd.Flags().StringSliceVarP(&prOpts.Labels, "label", "l", nil, "add these labels")
+	prCmd.Flags().BoolVar(&prOpts.NoEdit, "no-edit", false, "submit the generated title and body without editing")
+	prCmd.Flags().BoolVar(&prOpts.NoSuggest, "no-suggest", false, "don't request the suggested reviewers")
+}
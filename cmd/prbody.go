@@ -0,0 +1,30 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package cmd
+
+import (
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var prBodyOpts tui.PRBodyOptions
+
+// prBodyCmd represents the pr-body command
+var prBodyCmd = &cobra.Command{
+	Use:   "pr-body",
+	Short: "Generate a pull request description from your commits",
+	Long: `This command drafts a pull request description from the commits on the current
+branch: grouped by Conventional Commit type, with linked issue keys, touched areas
+and the checklist from .gitmate.yml. It works entirely offline.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return tui.RunPRBody(cmd.Context(), prBodyOpts)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(prBodyCmd)
+
+	prBodyCmd.Flags().BoolVarP(&prBodyOpts.Clipboard, "clipboard", "c", false, "copy the description to the clipboard")
+	prBodyCmd.Flags().StringVarP(&prBodyOpts.OutFile, "out", "o", "", "write the description to a file")
+}
@@ -0,0 +1,36 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package cmd
+
+import (
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var pruneOpts tui.PruneOptions
+var pruneUndo bool
+
+// pruneCmd represents the prune-branches command
+var pruneCmd = &cobra.Command{
+	Use:   "prune-branches",
+	Short: "Delete merged, gone and stale branches",
+	Long: `This command finds local branches that are merged (or squash-merged) into trunk,
+whose upstream is gone, or that have not been touched for a while, and lets you
+pick which ones to delete. Deleted branch tips are recorded so they can be restored
+with --undo.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if pruneUndo {
+			return tui.UndoPrune(cmd.Context())
+		}
+		return tui.RunPruneTUI(cmd.Context(), pruneOpts)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(pruneCmd)
+
+	pruneCmd.Flags().IntVar(&pruneOpts.StaleDays, "days", 30, "offer branches without commits for this many days (0 disables)")
+	pruneCmd.Flags().BoolVar(&pruneOpts.Remote, "remote", false, "also delete the branches on their remote")
+	pruneCmd.Flags().BoolVar(&pruneUndo, "undo", false, "restore the branches deleted by the last prune")
+}
@@ -0,0 +1,26 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package cmd
+
+import (
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// pushCmd represents the push command
+var pushCmd = &cobra.Command{
+	Use:   "push",
+	Short: "Push the current branch safely",
+	Long: `This command pushes the current branch, setting its upstream when missing.
+When sync or clean rewrote history it pushes with --force-with-lease pinned to the
+remote commit GitMate last saw. Pushes to protected branches (push.protected in
+.gitmate.yml) are refused, and the commits to be published are shown first.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return tui.RunPushTUI(cmd.Context())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(pushCmd)
+}
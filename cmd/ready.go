@@ -0,0 +1,28 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package cmd
+
+import (
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// readyCmd represents the ready command
+var readyCmd = &cobra.Command{
+	Use:   "ready",
+	Short: "Check whether the current branch is ready for a pull request",
+	Long: `This command runs a pre-PR checklist against the current branch: up to date
+with trunk, no noisy or merge commits, lint-clean messages, no conflict markers or
+debug statements, a reviewable diff size, a policy-conforming branch name and
+everything pushed. Failing items can be fixed with a single key.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return tui.RunReadyTUI(cmd.Context())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(readyCmd)
+
+	addJSONFlag(readyCmd)
+}
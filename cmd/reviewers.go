@@ -0,0 +1,32 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package cmd
+
+import (
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var reviewersOpts tui.ReviewersOptions
+
+// reviewersCmd represents the reviewers command
+var reviewersCmd = &cobra.Command{
+	Use:   "reviewers",
+	Short: "Suggest reviewers for the current branch",
+	Long: `This command looks at the files changed since the branch left trunk and ranks
+people to review them. CODEOWNERS (GitHub or GitLab syntax, in the repository
+root, .github/, docs/ or .gitlab/) counts most; recent commits to and blame of
+the changed files add to the score. Each suggestion lists its reasons.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return tui.RunReviewers(cmd.Context(), reviewersOpts)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(reviewersCmd)
+
+	reviewersCmd.Flags().StringVar(&reviewersOpts.Base, "base", "", "branch to compare against (default: trunk)")
+	reviewersCmd.Flags().IntVarP(&reviewersOpts.Limit, "limit", "n", 5, "number of suggestions to show")
+	addJSONFlag(reviewersCmd)
+}
@@ -5,37 +5,158 @@ package cmd
 
 import (
 	"context"
-	"fmt"
+	"io"
 	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
 
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/config"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/failure"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/git"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/hosting"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/sessionlog"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/tracker"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/tui"
 	"github.com/charmbracelet/fang"
+	"github.com/charmbracelet/x/term"
 	"github.com/spf13/cobra"
 )
 
+var (
+	runMode tui.RunMode
+	noTUI   bool
+	output  string
+	timeout time.Duration
+)
+
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
 	Use:   "GitMate",
 	Short: "GitMate – your Git companion with focus workflows",
-	Long:  `GitMate is a CLI/TUI tool to guide teams and individuals towards disciplined, opinionated Git workflows.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Run `gitmate --help` to see available commands")
+	Long: `GitMate is a CLI/TUI tool to guide teams and individuals towards disciplined, opinionated Git workflows.
+
+Run without a command in a terminal, GitMate opens a menu of its workflows and
+those of .gitmate.yml, and comes back to it after each one.
+
+Without a terminal (or with --no-tui) commands log plain lines instead of
+opening a TUI. Prompts are then answered with --on-dirty and --yes, and the exit
+code tells what went wrong:
+
+  0  success
+  1  unclassified error
+  2  usage: bad arguments, or a prompt left unanswered
+  3  uncommitted changes were in the way
+  4  a rebase or merge stopped on conflicts
+  5  a git command failed
+  6  fetching, pushing or the hosting service failed
+  7  CI checks or the readiness checklist failed
+  8  a git command or API request ran longer than its timeout
+  130 interrupted with ctrl+c or SIGTERM
+
+With --output json every git command, output line, warning and message is
+written to stdout as one JSON object per line, ending with a "result" event that
+lists the steps run, the resulting refs and any error with its kind.
+
+Each git command is stopped when it runs longer than its timeout: 10 minutes for
+fetch, pull, push and clone, no limit for commands that may wait on an editor
+(commit, rebase, merge, cherry-pick, revert) and 30 seconds for the rest. Change
+them under timeouts: in .gitmate.yml. --timeout replaces the 30 seconds for the
+rest and bounds every GitHub, GitLab and Jira request.
+On ctrl+c the running git command is asked to stop and killed if it doesn't.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if runMode.OnDirty != "" && !slices.Contains(tui.DirtyActions, runMode.OnDirty) {
+			return failure.Errorf(failure.Usage, "invalid argument %q for --on-dirty: want %s",
+				runMode.OnDirty, strings.Join(tui.DirtyActions, ", "))
+		}
+		if f := cmd.Flags().Lookup("json"); f != nil && f.Changed && f.Value.String() == "true" {
+			output = tui.OutputJSON
+		}
+		if output != tui.OutputText && output != tui.OutputJSON {
+			return failure.Errorf(failure.Usage, "invalid argument %q for --output: want text or json", output)
+		}
+		// a broken config is reported by the commands that need it
+		cfg, err := config.Load(cmd.Context(), ".")
+		if err != nil {
+			cfg = config.Default()
+		}
+		// --timeout replaces the default; the per-command timeouts stay, so
+		// commands waiting on an editor keep having no limit
+		timeouts := git.Timeouts{Default: cfg.Timeouts.Default, Commands: cfg.Timeouts.Git}
+		if cmd.Flags().Changed("timeout") {
+			timeouts.Default = timeout
+			hosting.SetTimeout(timeout)
+			tracker.SetTimeout(timeout)
+		}
+		git.SetTimeouts(timeouts)
+		if err := tui.SetTheme(cfg.UI); err != nil {
+			return err
+		}
+		if err := tui.SetKeys(cfg.UI); err != nil {
+			return err
+		}
+		runMode.Headless = noTUI || output == tui.OutputJSON ||
+			!term.IsTerminal(os.Stdin.Fd()) || !term.IsTerminal(os.Stdout.Fd())
+		tui.SetRunMode(runMode)
+		tui.StartOutput(output, strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" "))
+		if cmd != logsCmd && !strings.HasPrefix(cmd.Name(), "__") {
+			// logging is best effort; `logs` itself and shell completion stay out of it
+			if sessionlog.Start(append([]string{"gitmate"}, os.Args[1:]...)) == nil {
+				git.SetCommandHook(sessionlog.CommandDone)
+			}
+		}
+		return nil
 	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return tui.RunHomeTUI(cmd.Context())
+	},
+}
+
+// addJSONFlag adds --json, the older spelling of --output json, to cmd.
+func addJSONFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("json", false, "same as --output json")
+	_ = cmd.Flags().MarkDeprecated("json", "use --output json instead")
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
-	if err := fang.Execute(context.Background(), rootCmd); err != nil {
-		os.Exit(1)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	addWorkflowCommands(ctx)
+	err := fang.Execute(ctx, rootCmd, fang.WithErrorHandler(errorHandler))
+	stop()
+	tui.FinishOutput(ctx, err)
+	sessionlog.Finish(err)
+	if err != nil {
+		os.Exit(failure.ExitCode(err))
 	}
 }
 
+// errorHandler prints errors like fang does, except those a TUI already showed.
+func errorHandler(w io.Writer, styles fang.Styles, err error) {
+	if failure.IsShown(err) {
+		return
+	}
+	fang.DefaultErrorHandler(w, styles, err)
+}
+
 func init() {
 	// Here you will define your flags and configuration settings.
 	// Cobra supports persistent flags, which, if defined here,
 	// will be global for your application.
 
 	// rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.GitMate.yaml)")
+	rootCmd.PersistentFlags().BoolVar(&noTUI, "no-tui", false, "log plain lines instead of opening a TUI (default when not in a terminal)")
+	rootCmd.PersistentFlags().StringVar(&runMode.OnDirty, "on-dirty", "", "handle uncommitted changes without asking: stash, commit, discard or abort")
+	rootCmd.PersistentFlags().BoolVarP(&runMode.Yes, "yes", "y", false, "answer yes to confirmations")
+	rootCmd.PersistentFlags().StringVar(&output, "output", tui.OutputText, "output format: text, or json for JSON Lines events")
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 0, "timeout for API requests and for git commands without their own timeout in the config; 0 disables them")
+
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return failure.New(failure.Usage, err)
+	})
 
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
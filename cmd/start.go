@@ -10,14 +10,18 @@ import (
 
 // startCmd represents the start command
 var startCmd = &cobra.Command{
-	Use:   "start",
+	Use:   "start [feature-name | issue-key]",
 	Short: "Start a new feature branch workflow",
-	Long:  ` This command will start a new workflow.`,
+	Long: ` This command will start a new workflow.
+
+Pass an issue key (PROJ-123 or #45) to name the branch after the issue's title
+and prefix your commit messages with the key. PROJ-123 keys need jira.url in
+.gitmate.yml; when the issue can't be fetched the branch is named after the key.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) == 0 {
-			return tui.RunStartTUI("")
+			return tui.RunStartTUI(cmd.Context(), "")
 		}
-		return tui.RunStartTUI(args[0])
+		return tui.RunStartTUI(cmd.Context(), args[0])
 	},
 }
 
@@ -0,0 +1,23 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package cmd
+
+import (
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// statusCmd represents the status command
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show a dashboard of the current branch and its CI checks",
+	Long:  `This command shows where the current branch stands against trunk and its upstream, uncommitted changes, and the CI checks for HEAD.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return tui.RunStatusTUI(cmd.Context())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+}
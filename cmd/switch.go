@@ -0,0 +1,29 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package cmd
+
+import (
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// switchCmd represents the switch command
+var switchCmd = &cobra.Command{
+	Use:   "switch [branch]",
+	Short: "Switch branches with a fuzzy-searchable picker",
+	Long: `This command lists local and remote branches with their last commit and
+how far they are ahead/behind trunk. Picking a remote-only branch creates a
+local tracking branch. Pass a branch name to switch without the picker.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return tui.RunSwitchTUI(cmd.Context(), "")
+		}
+		return tui.RunSwitchTUI(cmd.Context(), args[0])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(switchCmd)
+}
@@ -11,10 +11,10 @@ import (
 // syncCmd represents the sync command
 var syncCmd = &cobra.Command{
 	Use:   "sync",
-	Short: "Sync your branch with main",
-	Long:  `This command will sync your branch with main.`,
+	Short: "Sync your branch with the trunk",
+	Long:  `This command will sync your branch with the trunk (trunk: in .gitmate.yml, or origin's default branch).`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return tui.RunSyncTUI()
+		return tui.RunSyncTUI(cmd.Context())
 	},
 }
 
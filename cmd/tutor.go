@@ -8,26 +8,61 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var tutorOpts tui.TutorOptions
+
 // tutorCmd represents the tutor command
 var tutorCmd = &cobra.Command{
-	Use:   "tutor",
+	Use:   "tutor [lesson]",
 	Short: "Interactive tutorial for Git workflows",
-	Long:  `This command will guide you through the basics of Git workflows.`,
+	Long: `This command will guide you through Git workflows, one lesson at a time. Every
+lesson runs in a throwaway practice repository (with its own local "origin")
+created in a temp directory. Each step explains a concept and the commands to
+try; run them there in another terminal and the step completes as soon as the
+repository looks right. Challenge lessons state a goal instead: press c to check
+your solution and h for hints, both of which count towards your score. Quizzes
+are answered with the number keys. The practice repository is deleted when you
+finish unless you pass --keep (or press k); if you quit part way it is kept and
+the next run resumes where you left off. See your skill map with ` + "`gitmate tutor progress`" + `.
+
+Lessons ship with GitMate. Add your own as YAML or Markdown files in the
+gitmate/lessons directory under your user config dir, or in the directories
+listed under tutor.lessonDirs in config. A lesson with a builtin id replaces it.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return tui.RunCleanTUI()
+		id := ""
+		if len(args) == 1 {
+			id = args[0]
+		}
+		return tui.RunTutorTUI(cmd.Context(), id, tutorOpts)
 	},
 }
 
-func init() {
-	rootCmd.AddCommand(tutorCmd)
+// tutorListCmd represents the tutor list command
+var tutorListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List available lessons",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return tui.ListLessons(cmd.Context())
+	},
+}
 
-	// Here you will define your flags and configuration settings.
+// tutorProgressCmd represents the tutor progress command
+var tutorProgressCmd = &cobra.Command{
+	Use:   "progress",
+	Short: "Show your lesson progress and skill map",
+	Long: `This command shows which lessons you have completed, how long they took and
+how many hints you used, grouped into a map of skills. With --output json the
+result event carries the same data for onboarding dashboards.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return tui.RunTutorProgress(cmd.Context())
+	},
+}
 
-	// Cobra supports Persistent Flags which will work for this command
-	// and all subcommands, e.g.:
-	// tutorCmd.PersistentFlags().String("foo", "", "A help for foo")
+func init() {
+	rootCmd.AddCommand(tutorCmd)
+	tutorCmd.AddCommand(tutorListCmd)
+	tutorCmd.AddCommand(tutorProgressCmd)
 
-	// Cobra supports local flags which will only run when this command
-	// is called directly, e.g.:
-	// tutorCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+	addJSONFlag(tutorProgressCmd)
+	tutorCmd.Flags().BoolVar(&tutorOpts.Keep, "keep", false, "keep the practice repository for inspection")
 }
@@ -0,0 +1,70 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/config"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/failure"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// addWorkflowCommands registers the workflows of .gitmate.yml as subcommands.
+// Built-in commands win over workflows of the same name.
+func addWorkflowCommands(ctx context.Context) {
+	cfg, err := config.Load(ctx, ".")
+	if err != nil {
+		// the commands that read the config report it
+		return
+	}
+	for name, w := range cfg.Workflows {
+		if taken(name) {
+			continue
+		}
+		short := w.Short
+		if short == "" {
+			short = "Run the " + name + " workflow from " + config.FileName
+		}
+		use := name
+		for _, a := range w.Args {
+			use += " <" + a + ">"
+		}
+		long := strings.TrimSpace(w.Explain)
+		if long == "" {
+			long = short + "."
+		}
+		long += fmt.Sprintf("\n\nThis workflow is defined in %s. Its steps can use {%s}.",
+			config.FileName, strings.Join(slices.Concat(tui.WorkflowVars, w.Args), "}, {"))
+
+		rootCmd.AddCommand(&cobra.Command{
+			Use:   use,
+			Short: short,
+			Long:  long,
+			Args: func(cmd *cobra.Command, args []string) error {
+				if err := cobra.ExactArgs(len(w.Args))(cmd, args); err != nil {
+					return failure.New(failure.Usage, err)
+				}
+				return nil
+			},
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return tui.RunWorkflowTUI(cmd.Context(), name, args)
+			},
+		})
+	}
+}
+
+// taken reports whether a built-in command already has name.
+func taken(name string) bool {
+	for _, c := range rootCmd.Commands() {
+		if c.Name() == name || c.HasAlias(name) {
+			return true
+		}
+	}
+	return name == "help" || name == "completion"
+}
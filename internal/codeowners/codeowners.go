@@ -0,0 +1,193 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+
+// Package codeowners parses CODEOWNERS files in GitHub and GitLab syntax and
+// matches paths against them.
+package codeowners
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Locations are the paths, relative to the repository root, where GitHub and
+// GitLab look for a CODEOWNERS file, in lookup order.
+var Locations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS", ".gitlab/CODEOWNERS"}
+
+// Rule is one pattern line.
+type Rule struct {
+	Pattern string
+	Owners  []string
+	Section string // GitLab section name; empty for GitHub files and the default section
+	Line    int
+
+	re *regexp.Regexp
+}
+
+// File is a parsed CODEOWNERS file.
+type File struct {
+	Path  string
+	Rules []Rule
+}
+
+var sectionRe = regexp.MustCompile(`^\^?\[([^\]]+)\](?:\[\d+\])?\s*(.*)$`)
+
+// Find loads the first CODEOWNERS file found under root. It returns nil, nil when
+// the repository has none.
+func Find(root string) (*File, error) {
+	for _, loc := range Locations {
+		path := filepath.Join(root, loc)
+		f, err := os.Open(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		file, err := Parse(f)
+		if err != nil {
+			return nil, err
+		}
+		file.Path = loc
+		return file, nil
+	}
+	return nil, nil
+}
+
+// Parse reads CODEOWNERS rules. GitLab sections ([Name] @default-owners) are
+// supported; rules without owners inherit the section's default owners.
+func Parse(r io.Reader) (*File, error) {
+	file := &File{}
+	section := ""
+	var defaults []string
+	scanner := bufio.NewScanner(r)
+	n := 0
+	for scanner.Scan() {
+		n++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if m := sectionRe.FindStringSubmatch(line); m != nil {
+			section = m[1]
+			defaults = strings.Fields(stripComment(m[2]))
+			continue
+		}
+		pattern, owners := splitRule(stripComment(line))
+		if pattern == "" {
+			continue
+		}
+		if len(owners) == 0 {
+			owners = defaults
+		}
+		re, err := compile(pattern)
+		if err != nil {
+			continue
+		}
+		file.Rules = append(file.Rules, Rule{Pattern: pattern, Owners: owners, Section: section, Line: n, re: re})
+	}
+	return file, scanner.Err()
+}
+
+// splitRule splits a rule into its pattern and owners. The pattern ends at the
+// first space not escaped with a backslash, so "\ " matches a literal space.
+func splitRule(line string) (string, []string) {
+	var pattern strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case c == '\\' && i+1 < len(line) && line[i+1] == ' ':
+			pattern.WriteByte(' ')
+			i++
+		case c == ' ' || c == '\t':
+			return pattern.String(), strings.Fields(line[i:])
+		default:
+			pattern.WriteByte(c)
+		}
+	}
+	return pattern.String(), nil
+}
+
+func stripComment(s string) string {
+	if i := strings.Index(s, " #"); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
+// Match returns the rules that apply to path: the last matching rule overall for
+// GitHub files, or the last matching rule of every section for GitLab files.
+func (f *File) Match(path string) []Rule {
+	path = strings.TrimPrefix(filepath.ToSlash(path), "/")
+	last := map[string]int{}
+	var order []string
+	for i, r := range f.Rules {
+		if r.re.MatchString(path) {
+			if _, seen := last[r.Section]; !seen {
+				order = append(order, r.Section)
+			}
+			last[r.Section] = i
+		}
+	}
+	var res []Rule
+	for _, s := range order {
+		if r := f.Rules[last[s]]; len(r.Owners) > 0 {
+			res = append(res, r)
+		}
+	}
+	return res
+}
+
+// compile converts a gitignore-style pattern into a regular expression over
+// slash-separated paths relative to the repository root.
+func compile(pattern string) (*regexp.Regexp, error) {
+	anchored := strings.HasPrefix(pattern, "/")
+	p := strings.TrimPrefix(pattern, "/")
+	dirOnly := strings.HasSuffix(p, "/")
+	p = strings.TrimSuffix(p, "/")
+	// a slash anywhere but the end anchors the pattern to the root, as in gitignore
+	if strings.Contains(p, "/") {
+		anchored = true
+	}
+
+	var b strings.Builder
+	if anchored {
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+	for i := 0; i < len(p); i++ {
+		switch c := p[i]; c {
+		case '*':
+			if i+1 < len(p) && p[i+1] == '*' {
+				// "**/" matches zero or more directories, a trailing "**" everything
+				if i+2 < len(p) && p[i+2] == '/' {
+					b.WriteString("(?:.*/)?")
+					i += 2
+				} else {
+					b.WriteString(".*")
+					i++
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	if dirOnly {
+		b.WriteString("/.*$")
+	} else {
+		// a pattern naming a directory also covers everything inside it
+		b.WriteString("(?:/.*)?$")
+	}
+	return regexp.Compile(b.String())
+}
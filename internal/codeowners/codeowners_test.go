@@ -0,0 +1,132 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package codeowners
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// owners returns the owners of the rules matching path, one string per rule.
+func owners(f *File, path string) []string {
+	var res []string
+	for _, r := range f.Match(path) {
+		res = append(res, strings.Join(r.Owners, " "))
+	}
+	return res
+}
+
+func TestMatch(t *testing.T) {
+	const github = `# owners of everything
+*                   @acme/core
+*.go                @gophers
+/docs/              @writers
+build/              @ci
+/scripts/*.sh       @ops
+**/testdata/**      @qa
+cmd/**/main.go      @cli
+README.md           @writers # the readme
+/assets/my\ logo.png @design
+/vendor/
+`
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"LICENSE", []string{"@acme/core"}},
+		{"main.go", []string{"@gophers"}},
+		{"internal/git/git.go", []string{"@gophers"}},
+		{"docs/intro.md", []string{"@writers"}},
+		{"docs/api/v1.md", []string{"@writers"}},
+		// /docs/ is anchored, so a nested docs directory keeps the default owners
+		{"internal/docs/x.md", []string{"@acme/core"}},
+		// build/ is not anchored and matches at any depth
+		{"build/out.txt", []string{"@ci"}},
+		{"web/build/out.txt", []string{"@ci"}},
+		{"scripts/release.sh", []string{"@ops"}},
+		{"scripts/ci/release.sh", []string{"@acme/core"}},
+		{"internal/testdata/golden.txt", []string{"@qa"}},
+		{"cmd/main.go", []string{"@cli"}},
+		{"cmd/gitmate/main.go", []string{"@cli"}},
+		{"README.md", []string{"@writers"}},
+		{"/README.md", []string{"@writers"}},
+		{"assets/my logo.png", []string{"@design"}},
+		{"assets/logo.png", []string{"@acme/core"}},
+		// a later rule without owners unsets them
+		{"vendor/lib/lib.go", nil},
+	}
+	f, err := Parse(strings.NewReader(github))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		if got := owners(f, tt.path); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("owners of %s = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestMatchGitLabSections(t *testing.T) {
+	const gitlab = `* @acme/core
+
+[Backend] @backend-leads
+internal/
+internal/tui/ @frontend
+
+^[Docs][2] @writers
+*.md
+`
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"go.mod", []string{"@acme/core"}},
+		// the last match of every section applies
+		{"internal/git/git.go", []string{"@acme/core", "@backend-leads"}},
+		{"internal/tui/keys.go", []string{"@acme/core", "@frontend"}},
+		{"internal/README.md", []string{"@acme/core", "@backend-leads", "@writers"}},
+	}
+	f, err := Parse(strings.NewReader(gitlab))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		if got := owners(f, tt.path); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("owners of %s = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+	if s := f.Match("internal/README.md")[2].Section; s != "Docs" {
+		t.Errorf("section = %q, want Docs", s)
+	}
+}
+
+func TestFind(t *testing.T) {
+	root := t.TempDir()
+	if f, err := Find(root); f != nil || err != nil {
+		t.Fatalf("Find without a file = %v, %v; want nil, nil", f, err)
+	}
+	// .github/CODEOWNERS wins over the root one
+	for path, content := range map[string]string{
+		"CODEOWNERS":         "* @root\n",
+		".github/CODEOWNERS": "* @github\n",
+	} {
+		p := filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	f, err := Find(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Path != ".github/CODEOWNERS" || !reflect.DeepEqual(owners(f, "x"), []string{"@github"}) {
+		t.Errorf("Find = %s with owners %q, want .github/CODEOWNERS", f.Path, owners(f, "x"))
+	}
+}
@@ -0,0 +1,226 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/git"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/workflow"
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the per-repository config file, looked up at the repository root.
+const FileName = ".gitmate.yml"
+
+// Config holds GitMate settings. The user config is loaded first and the
+// repository's .gitmate.yml is layered on top, so teams can pin shared policy.
+type Config struct {
+	Trunk  string       `yaml:"trunk"`  // trunk branch; detected from origin/HEAD when empty
+	Branch BranchConfig `yaml:"branch"` // branch naming policy
+	Ready  ReadyConfig  `yaml:"ready"`  // `gitmate ready` thresholds
+	Push   PushConfig   `yaml:"push"`   // `gitmate push` safety rails
+	PR     PRConfig     `yaml:"pr"`     // pull request descriptions
+	// Hosting forces the hosting service ("github" or "gitlab") when it cannot be
+	// detected from the remote host.
+	Hosting string       `yaml:"hosting"`
+	GitHub  GitHubConfig `yaml:"github"` // GitHub API access
+	GitLab  GitLabConfig `yaml:"gitlab"` // GitLab API access
+	Jira    JiraConfig   `yaml:"jira"`   // Jira issue lookup for `gitmate start PROJ-123`
+	Tutor   TutorConfig  `yaml:"tutor"`  // `gitmate tutor` lessons
+	Coach   CoachConfig  `yaml:"coach"`  // `gitmate coach` habits analysis
+	// Timeouts bound how long git commands may run; --timeout overrides them.
+	Timeouts TimeoutsConfig `yaml:"timeouts"`
+	// Workflows are team defined workflows, each available as `gitmate <name>`.
+	Workflows map[string]workflow.Workflow `yaml:"workflows"`
+	UI        UIConfig                     `yaml:"ui"` // look of the TUI
+}
+
+// BranchConfig describes the branch naming policy.
+type BranchConfig struct {
+	Pattern string `yaml:"pattern"` // regular expression branch names must match
+}
+
+// ReadyConfig configures the pre-PR readiness checks.
+type ReadyConfig struct {
+	MaxDiffLines  int      `yaml:"maxDiffLines"`  // added+removed lines allowed before the diff counts as too large
+	DebugPatterns []string `yaml:"debugPatterns"` // regular expressions that flag leftover debug statements
+}
+
+// PushConfig configures `gitmate push`.
+type PushConfig struct {
+	Remote    string   `yaml:"remote"`    // remote used when the branch has no upstream
+	Protected []string `yaml:"protected"` // branch globs GitMate refuses to push to
+}
+
+// PRConfig configures generated pull request descriptions.
+type PRConfig struct {
+	Checklist []string `yaml:"checklist"` // items rendered as an unchecked task list
+}
+
+// GitHubConfig configures access to the GitHub REST API.
+type GitHubConfig struct {
+	APIURL string `yaml:"apiURL"` // API base URL; derived from the origin remote when empty
+	Token  string `yaml:"token"`  // token used when neither env nor credential helper has one
+}
+
+// GitLabConfig configures access to the GitLab REST API.
+type GitLabConfig struct {
+	APIURL string `yaml:"apiURL"` // API base URL; https://<remote host>/api/v4 when empty
+	Token  string `yaml:"token"`  // token used when neither env nor credential helper has one
+}
+
+// JiraConfig configures the Jira issue tracker.
+type JiraConfig struct {
+	URL   string `yaml:"url"`   // site URL, e.g. https://acme.atlassian.net
+	User  string `yaml:"user"`  // account email for Jira Cloud; empty to send the token as a bearer PAT
+	Token string `yaml:"token"` // API token; JIRA_TOKEN takes precedence
+	// Projects are the project keys `gitmate start` takes PROJ-123 keys of;
+	// any key when empty.
+	Projects []string `yaml:"projects"`
+}
+
+// TutorConfig configures `gitmate tutor`.
+type TutorConfig struct {
+	LessonDirs []string `yaml:"lessonDirs"` // extra directories of lesson files
+}
+
+// CoachConfig configures `gitmate coach`.
+type CoachConfig struct {
+	Summary bool `yaml:"summary"` // print the top tip after sync and clean
+	Days    int  `yaml:"days"`    // days of activity to analyse
+}
+
+// TimeoutsConfig bounds how long git commands run, e.g. `pull: 15m` for a big
+// monorepo. A zero timeout means no limit.
+type TimeoutsConfig struct {
+	Default time.Duration            `yaml:"default"` // git commands not listed below
+	Git     map[string]time.Duration `yaml:"git"`     // per git subcommand
+}
+
+// UIConfig configures the look and keys of the TUI.
+type UIConfig struct {
+	// Theme is "auto", "dark", "light", "high-contrast" or one of Themes.
+	// NO_COLOR in the environment turns colors off whatever the theme.
+	Theme  string                 `yaml:"theme"`
+	Themes map[string]ThemeConfig `yaml:"themes"` // user defined themes
+	// Keymap is the preset of key bindings: "default", "vim" or "emacs".
+	Keymap string `yaml:"keymap"`
+	// Keys rebinds actions on top of the preset, e.g. `quit: [q, esc]`; an
+	// empty list unbinds the action. `?` in the TUI lists the actions.
+	Keys map[string][]string `yaml:"keys"`
+}
+
+// ThemeConfig is a user defined theme. Colors are hex ("#6f03fc") or ANSI
+// numbers ("241"); those left empty come from Base.
+type ThemeConfig struct {
+	Base    string `yaml:"base"`    // preset the theme starts from; auto when empty
+	Accent  string `yaml:"accent"`  // selections, commands and progress bars
+	Muted   string `yaml:"muted"`   // secondary text
+	Success string `yaml:"success"` // passed checks and finished steps
+	Warning string `yaml:"warning"` // stderr output
+	Error   string `yaml:"error"`   // errors and failed checks
+}
+
+// Default returns the built-in configuration.
+func Default() Config {
+	return Config{
+		Branch: BranchConfig{
+			Pattern: `^(feature|fix|bugfix|hotfix|chore|docs|refactor|release)/[a-z0-9._-]+$`,
+		},
+		Push: PushConfig{
+			Remote:    "origin",
+			Protected: []string{"main", "master", "release/*"},
+		},
+		PR: PRConfig{
+			Checklist: []string{
+				"Tests added or updated",
+				"Documentation updated",
+				"Ran `gitmate ready`",
+			},
+		},
+		Coach: CoachConfig{
+			Days: 30,
+		},
+		UI: UIConfig{
+			Theme:  "auto",
+			Keymap: "default",
+		},
+		Timeouts: TimeoutsConfig{
+			Default: 30 * time.Second,
+			Git: map[string]time.Duration{
+				"fetch": 10 * time.Minute,
+				"pull":  10 * time.Minute,
+				"push":  10 * time.Minute,
+				"clone": 10 * time.Minute,
+				// these can open an editor and wait for the user
+				"commit":      0,
+				"rebase":      0,
+				"merge":       0,
+				"cherry-pick": 0,
+				"revert":      0,
+			},
+		},
+		Ready: ReadyConfig{
+			MaxDiffLines: 400,
+			DebugPatterns: []string{
+				`console\.log\(`,
+				`\bdebugger\b`,
+				`binding\.pry`,
+				`pdb\.set_trace\(`,
+				`\bbreakpoint\(\)`,
+				`var_dump\(`,
+			},
+		},
+	}
+}
+
+// UserPath returns the location of the per-user config file.
+func UserPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gitmate", "config.yml"), nil
+}
+
+// Load returns the defaults overlaid with the user config and the .gitmate.yml at
+// the root of the repository containing dir. Missing files are not an error.
+func Load(ctx context.Context, dir string) (Config, error) {
+	cfg := Default()
+	if p, err := UserPath(); err == nil {
+		if err := loadFile(p, &cfg); err != nil {
+			return cfg, err
+		}
+	}
+	if root, err := git.RunCombined(ctx, dir, "rev-parse", "--show-toplevel"); err == nil {
+		if err := loadFile(filepath.Join(root, FileName), &cfg); err != nil {
+			return cfg, err
+		}
+	}
+	if cfg.Trunk == "" {
+		cfg.Trunk = git.DefaultBranch(ctx, dir)
+	}
+	return cfg, nil
+}
+
+// loadFile unmarshals path over cfg; only keys present in the file are overwritten.
+func loadFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return nil
+}
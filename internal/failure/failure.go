@@ -0,0 +1,111 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+
+// Package failure classifies errors so that every kind of failure exits with its
+// own status code. Scripts and CI jobs can then react to, say, a rebase conflict
+// differently from an unreachable remote without parsing messages.
+package failure
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kind is a class of failure. Its value is the process exit code.
+type Kind int
+
+const (
+	Unknown     Kind = 1   // anything not classified below
+	Usage       Kind = 2   // bad flags or arguments, or a prompt left unanswered in headless mode
+	Dirty       Kind = 3   // uncommitted changes were in the way (--on-dirty=abort)
+	Conflict    Kind = 4   // a rebase, merge or cherry-pick stopped on conflicts
+	Git         Kind = 5   // a local git command failed
+	Remote      Kind = 6   // fetching, pushing or talking to the hosting service failed
+	Checks      Kind = 7   // CI checks or the readiness checklist failed
+	Timeout     Kind = 8   // a git command or API request ran longer than its timeout
+	Interrupted Kind = 130 // stopped with ctrl+c or SIGTERM; 128+SIGINT, like shells report it
+)
+
+// String names the kind, e.g. for machine-readable output.
+func (k Kind) String() string {
+	switch k {
+	case Usage:
+		return "usage"
+	case Dirty:
+		return "dirty"
+	case Conflict:
+		return "conflict"
+	case Git:
+		return "git"
+	case Remote:
+		return "remote"
+	case Checks:
+		return "checks"
+	case Timeout:
+		return "timeout"
+	case Interrupted:
+		return "interrupted"
+	}
+	return "unknown"
+}
+
+// Error is an error with a Kind.
+type Error struct {
+	Kind Kind
+	Err  error
+	// Shown is set when the error was already displayed (e.g. in a TUI), so the
+	// command line doesn't print it a second time.
+	Shown bool
+}
+
+func (e *Error) Error() string { return e.Err.Error() }
+func (e *Error) Unwrap() error { return e.Err }
+
+// New wraps err with kind. A nil err stays nil.
+func New(kind Kind, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Kind: kind, Err: err}
+}
+
+// Errorf formats an error of the given kind.
+func Errorf(kind Kind, format string, args ...any) error {
+	return &Error{Kind: kind, Err: fmt.Errorf(format, args...)}
+}
+
+// Shown marks err as already displayed to the user, keeping its kind.
+func Shown(err error) error {
+	if err == nil {
+		return nil
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return &Error{Kind: e.Kind, Err: e.Err, Shown: true}
+	}
+	return &Error{Kind: Unknown, Err: err, Shown: true}
+}
+
+// IsShown reports whether err was marked with Shown.
+func IsShown(err error) bool {
+	var e *Error
+	return errors.As(err, &e) && e.Shown
+}
+
+// KindOf returns the kind of err: Unknown for unclassified errors, 0 for nil.
+func KindOf(err error) Kind {
+	if err == nil {
+		return 0
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Kind
+	}
+	return Unknown
+}
+
+// ExitCode returns the process exit code for err.
+func ExitCode(err error) int {
+	return int(KindOf(err))
+}
@@ -0,0 +1,266 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Branch represents one ref from `git for-each-ref` together with its last commit.
+type Branch struct {
+	Name         string    // short ref name (e.g. feature/login or origin/feature/login)
+	Hash         string    // commit the ref points at
+	Upstream     string    // configured upstream (empty if none)
+	UpstreamGone bool      // upstream is configured but no longer exists on the remote
+	Remote       bool      // ref lives under refs/remotes
+	LastCommit   time.Time // committer date of the tip commit
+	Author       string    // author of the tip commit
+	Subject      string    // subject line of the tip commit
+}
+
+// branchFormat is the for-each-ref format parsed by parseBranches, fields separated by NUL.
+const branchFormat = "%(refname)%00%(refname:short)%00%(objectname)%00%(upstream:short)%00%(upstream:track)%00%(committerdate:unix)%00%(authorname)%00%(subject)"
+
+// ListBranches returns the local branches in dir.
+func ListBranches(ctx context.Context, dir string) ([]Branch, error) {
+	return listRefs(ctx, dir, "refs/heads")
+}
+
+// ListRemoteBranches returns the remote-tracking branches in dir, skipping symbolic HEAD refs.
+func ListRemoteBranches(ctx context.Context, dir string) ([]Branch, error) {
+	return listRefs(ctx, dir, "refs/remotes")
+}
+
+func listRefs(ctx context.Context, dir string, pattern string) ([]Branch, error) {
+	out, err := RunCombined(ctx, dir, "for-each-ref", "--format="+branchFormat, pattern)
+	if err != nil {
+		return nil, err
+	}
+	return parseBranches(out), nil
+}
+
+// parseBranches parses the output of `git for-each-ref --format=<branchFormat>`.
+func parseBranches(out string) []Branch {
+	var res []Branch
+	for _, ln := range strings.Split(out, "\n") {
+		if strings.TrimSpace(ln) == "" {
+			continue
+		}
+		f := strings.Split(ln, "\x00")
+		if len(f) < 8 {
+			continue
+		}
+		// refs/remotes/origin/HEAD is a pointer, not a branch
+		if strings.HasSuffix(f[0], "/HEAD") {
+			continue
+		}
+		b := Branch{
+			Name:         f[1],
+			Hash:         f[2],
+			Upstream:     f[3],
+			UpstreamGone: strings.Contains(f[4], "gone"),
+			Remote:       strings.HasPrefix(f[0], "refs/remotes/"),
+			Author:       f[6],
+			Subject:      f[7],
+		}
+		if ts, err := strconv.ParseInt(f[5], 10, 64); err == nil {
+			b.LastCommit = time.Unix(ts, 0)
+		}
+		res = append(res, b)
+	}
+	return res
+}
+
+// CurrentBranch returns the name of the checked-out branch ("HEAD" when detached).
+func CurrentBranch(ctx context.Context, dir string) (string, error) {
+	return RunCombined(ctx, dir, "rev-parse", "--abbrev-ref", "HEAD")
+}
+
+// DefaultBranch returns the trunk branch name. It prefers origin/HEAD, then falls back
+// to a local main or master, and finally to "main".
+func DefaultBranch(ctx context.Context, dir string) string {
+	if out, err := RunCombined(ctx, dir, "symbolic-ref", "--short", "refs/remotes/origin/HEAD"); err == nil && out != "" {
+		return strings.TrimPrefix(out, "origin/")
+	}
+	for _, name := range []string{"main", "master"} {
+		if RefExists(ctx, dir, "refs/heads/"+name) {
+			return name
+		}
+	}
+	return "main"
+}
+
+// TrunkRef returns the ref branches should be compared against: origin/<trunk> when it
+// exists, otherwise the local trunk branch.
+func TrunkRef(ctx context.Context, dir, trunk string) string {
+	if RefExists(ctx, dir, "refs/remotes/origin/"+trunk) {
+		return "origin/" + trunk
+	}
+	return trunk
+}
+
+// RefExists reports whether ref resolves to an object.
+func RefExists(ctx context.Context, dir, ref string) bool {
+	_, err := RunCombined(ctx, dir, "rev-parse", "--verify", "--quiet", ref)
+	return err == nil
+}
+
+// RevParse resolves ref to a full commit hash.
+func RevParse(ctx context.Context, dir, ref string) (string, error) {
+	return RunCombined(ctx, dir, "rev-parse", "--verify", ref+"^{commit}")
+}
+
+// MergeBase returns the best common ancestor of a and b.
+func MergeBase(ctx context.Context, dir, a, b string) (string, error) {
+	return RunCombined(ctx, dir, "merge-base", a, b)
+}
+
+// IsAncestor reports whether commit a is an ancestor of (or equal to) commit b.
+func IsAncestor(ctx context.Context, dir, a, b string) (bool, error) {
+	_, err := RunCombined(ctx, dir, "merge-base", "--is-ancestor", a, b)
+	if err == nil {
+		return true, nil
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return false, nil
+	}
+	return false, err
+}
+
+// IsSquashMerged reports whether the changes of branch already landed on trunk as a
+// single squashed commit. It builds a throwaway commit holding the branch tree on top
+// of the merge-base and asks `git cherry` whether trunk contains an equivalent patch.
+func IsSquashMerged(ctx context.Context, dir, branch, trunk string) (bool, error) {
+	base, err := MergeBase(ctx, dir, trunk, branch)
+	if err != nil {
+		return false, err
+	}
+	// identical trees means there is nothing left on the branch at all
+	baseTree, err := RunCombined(ctx, dir, "rev-parse", base+"^{tree}")
+	if err != nil {
+		return false, err
+	}
+	tree, err := RunCombined(ctx, dir, "rev-parse", branch+"^{tree}")
+	if err != nil {
+		return false, err
+	}
+	if tree == baseTree {
+		return false, nil
+	}
+	tmp, err := RunCombined(ctx, dir, "commit-tree", tree, "-p", base, "-m", "gitmate squash probe")
+	if err != nil {
+		return false, err
+	}
+	out, err := RunCombined(ctx, dir, "cherry", trunk, tmp)
+	if err != nil {
+		return false, err
+	}
+	return strings.HasPrefix(out, "-"), nil
+}
+
+// AheadBehind returns how many commits ref has that base lacks (ahead) and vice versa (behind).
+func AheadBehind(ctx context.Context, dir, base, ref string) (ahead int, behind int, err error) {
+	out, err := RunCombined(ctx, dir, "rev-list", "--left-right", "--count", base+"..."+ref)
+	if err != nil {
+		return 0, 0, err
+	}
+	f := strings.Fields(out)
+	if len(f) != 2 {
+		return 0, 0, fmt.Errorf("unexpected rev-list output: %q", out)
+	}
+	behind, _ = strconv.Atoi(f[0])
+	ahead, _ = strconv.Atoi(f[1])
+	return ahead, behind, nil
+}
+
+// GitDir returns the absolute path of the repository's .git directory.
+func GitDir(ctx context.Context, dir string) (string, error) {
+	out, err := RunCombined(ctx, dir, "rev-parse", "--absolute-git-dir")
+	if err != nil {
+		return "", err
+	}
+	return filepath.Clean(out), nil
+}
+
+// OperationInProgress names the multi-step operation git is in the middle of
+// ("rebase", "merge", "cherry-pick" or "revert"), or returns "".
+func OperationInProgress(ctx context.Context, dir string) string {
+	gitDir, err := GitDir(ctx, dir)
+	if err != nil {
+		return ""
+	}
+	markers := []struct{ path, op string }{
+		{"rebase-merge", "rebase"},
+		{"rebase-apply", "rebase"},
+		{"MERGE_HEAD", "merge"},
+		{"CHERRY_PICK_HEAD", "cherry-pick"},
+		{"REVERT_HEAD", "revert"},
+	}
+	for _, m := range markers {
+		if _, err := os.Stat(filepath.Join(gitDir, m.path)); err == nil {
+			return m.op
+		}
+	}
+	return ""
+}
+
+// SplitRemoteRef splits "origin/feature/x" into ("origin", "feature/x").
+func SplitRemoteRef(ref string) (remote string, branch string) {
+	remote, branch, ok := strings.Cut(ref, "/")
+	if !ok {
+		return "", ref
+	}
+	return remote, branch
+}
+
+// DiffNames returns the paths that differ between two commits.
+func DiffNames(ctx context.Context, dir, from, to string) ([]string, error) {
+	out, err := RunCombined(ctx, dir, "diff", "--name-only", from, to)
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+// BranchConfig reads branch.<branch>.<key> from the repository config, returning ""
+// when it is unset.
+func BranchConfig(ctx context.Context, dir, branch, key string) string {
+	out, err := RunCombined(ctx, dir, "config", "--get", "branch."+branch+"."+key)
+	if err != nil {
+		return ""
+	}
+	return out
+}
+
+// SetBranchConfig writes branch.<branch>.<key> to the repository config.
+func SetBranchConfig(ctx context.Context, dir, branch, key, value string) error {
+	_, err := RunCombined(ctx, dir, "config", "branch."+branch+"."+key, value)
+	return err
+}
+
+// UnsetBranchConfig removes branch.<branch>.<key>; a missing key is not an error.
+func UnsetBranchConfig(ctx context.Context, dir, branch, key string) error {
+	if BranchConfig(ctx, dir, branch, key) == "" {
+		return nil
+	}
+	_, err := RunCombined(ctx, dir, "config", "--unset", "branch."+branch+"."+key)
+	return err
+}
+
+// Upstream returns the remote-tracking ref configured for HEAD (e.g. origin/feature/x).
+func Upstream(ctx context.Context, dir string) (string, error) {
+	return RunCombined(ctx, dir, "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{upstream}")
+}
@@ -7,9 +7,11 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"runtime"
 	"strings"
 	"time"
 )
@@ -23,26 +25,120 @@ type FileStatus struct {
 	RawLine        string // original porcelain line
 }
 
-// Run runs `git <args...>` in dir with a default timeout and returns stdout, stderr and error.
-func Run(ctx context.Context, dir string, args ...string) (stdout string, stderr string, err error) {
-	// If caller didn't provide a context deadline, add a sensible timeout to avoid hanging.
-	// Caller can provide ctx with its own deadline to override.
-	var cancel context.CancelFunc
-	if _, ok := ctx.Deadline(); !ok {
-		ctx, cancel = context.WithTimeout(ctx, 8*time.Second)
-		defer cancel()
+// Timeouts bound how long git commands may run when the caller's context has
+// no deadline of its own. Zero means no limit.
+type Timeouts struct {
+	Default  time.Duration            // commands not listed in Commands
+	Commands map[string]time.Duration // per subcommand, e.g. "pull"
+}
+
+var timeouts = Timeouts{Default: 30 * time.Second}
+
+// SetTimeouts replaces the timeouts of all following git commands.
+func SetTimeouts(t Timeouts) { timeouts = t }
+
+// For returns the timeout for `git <args...>`.
+func (t Timeouts) For(args []string) time.Duration {
+	if d, ok := t.Commands[Subcommand(args)]; ok {
+		return d
+	}
+	return t.Default
+}
+
+// WithTimeout bounds ctx by the timeout configured for `git <args...>`, unless
+// ctx already has a deadline.
+func WithTimeout(ctx context.Context, args []string) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
 	}
+	if d := timeouts.For(args); d > 0 {
+		return context.WithTimeout(ctx, d)
+	}
+	return context.WithCancel(ctx)
+}
+
+// Subcommand returns the git subcommand of args, skipping -c and -C options.
+func Subcommand(args []string) string {
+	for i := 0; i < len(args); i++ {
+		if args[i] == "-c" || args[i] == "-C" {
+			i++
+			continue
+		}
+		return args[i]
+	}
+	return ""
+}
+
+// KillDelay is how long a cancelled command has to exit after it was
+// interrupted before it is killed.
+const KillDelay = 5 * time.Second
+
+// Graceful makes cmd, made with exec.CommandContext, stop like a user pressing
+// ctrl+c when its context ends: it is interrupted so git can clean up its lock
+// files, and only killed if it is still running after KillDelay.
+func Graceful(cmd *exec.Cmd) {
+	cmd.Cancel = func() error {
+		if runtime.GOOS == "windows" {
+			// there are no interrupts to send to a process on Windows
+			return cmd.Process.Kill()
+		}
+		return cmd.Process.Signal(os.Interrupt)
+	}
+	cmd.WaitDelay = KillDelay
+}
+
+// commandHook receives every git command run through this package.
+var commandHook func(argv []string, d time.Duration, exitCode int)
+
+// SetCommandHook makes f receive every following git command run through this
+// package once it ended, e.g. to log it: its argv starting with "git", how long
+// it ran and its exit code, -1 when it didn't start or was killed.
+func SetCommandHook(f func(argv []string, d time.Duration, exitCode int)) { commandHook = f }
+
+// ran reports a command started at start that ended with err to the hook.
+func ran(args []string, start time.Time, err error) {
+	if commandHook == nil {
+		return
+	}
+	code := 0
+	var exitErr *exec.ExitError
+	switch {
+	case errors.As(err, &exitErr):
+		code = exitErr.ExitCode()
+	case err != nil:
+		code = -1
+	}
+	commandHook(append([]string{"git"}, args...), time.Since(start), code)
+}
+
+// timedOut explains err when the command was stopped by its timeout.
+func timedOut(ctx context.Context, args []string, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("git %s timed out after %s (raise timeouts.git.%s in the config or pass --timeout): %w",
+			Subcommand(args), timeouts.For(args), Subcommand(args), err)
+	}
+	return err
+}
+
+// Run runs `git <args...>` in dir and returns stdout, stderr and error. Unless
+// ctx has a deadline, the command is bounded by its configured timeout.
+func Run(ctx context.Context, dir string, args ...string) (stdout string, stderr string, err error) {
+	ctx, cancel := WithTimeout(ctx, args)
+	defer cancel()
 
 	cmd := exec.CommandContext(ctx, "git", args...)
 	if dir != "" {
 		cmd.Dir = dir
 	}
+	Graceful(cmd)
 
 	var outBuf, errBuf bytes.Buffer
 	cmd.Stdout = &outBuf
 	cmd.Stderr = &errBuf
 
+	start := time.Now()
 	err = cmd.Run()
+	ran(args, start, err)
 	stdout = strings.TrimRight(outBuf.String(), "\n")
 	stderr = strings.TrimRight(errBuf.String(), "\n")
 
@@ -51,6 +147,7 @@ func Run(ctx context.Context, dir string, args ...string) (stdout string, stderr
 		if stderr != "" {
 			err = fmt.Errorf("%w: %s", err, stderr)
 		}
+		err = timedOut(ctx, args, err)
 	}
 	return
 }
@@ -62,8 +159,7 @@ func RunCombined(ctx context.Context, dir string, args ...string) (string, error
 }
 
 // GitStatusPorcelain runs `git status --porcelain` in the provided dir and returns parsed entries.
-func GitStatusPorcelain(dir string) ([]FileStatus, error) {
-	ctx := context.Background()
+func GitStatusPorcelain(ctx context.Context, dir string) ([]FileStatus, error) {
 	out, err := RunCombined(ctx, dir, "status", "--porcelain")
 	if err != nil {
 		return nil, err
@@ -121,10 +217,13 @@ func RunStream(ctx context.Context, dir string, args []string,
 	onStdout func(string),
 	onStderr func(string)) error {
 
+	ctx, cancel := WithTimeout(ctx, args)
+	defer cancel()
 	cmd := exec.CommandContext(ctx, "git", args...)
 	if dir != "" {
 		cmd.Dir = dir
 	}
+	Graceful(cmd)
 
 	stdoutPipe, err := cmd.StdoutPipe()
 	if err != nil {
@@ -135,7 +234,9 @@ func RunStream(ctx context.Context, dir string, args []string,
 		return fmt.Errorf("stderr pipe: %w", err)
 	}
 
+	start := time.Now()
 	if err := cmd.Start(); err != nil {
+		ran(args, start, err)
 		return fmt.Errorf("start git: %w", err)
 	}
 
@@ -162,15 +263,16 @@ func RunStream(ctx context.Context, dir string, args []string,
 	}()
 
 	// Wait for process to exit
-	if err := cmd.Wait(); err != nil {
-		return fmt.Errorf("git failed: %w", err)
+	err = cmd.Wait()
+	ran(args, start, err)
+	if err != nil {
+		return timedOut(ctx, args, fmt.Errorf("git failed: %w", err))
 	}
 	return nil
 }
 
 // Fetch runs `git fetch origin main`
-func Fetch(dir string) error {
-	ctx := context.Background()
+func Fetch(ctx context.Context, dir string) error {
 	_, err := RunCombined(ctx, dir, "fetch", "origin", "main")
 	if err != nil {
 		return fmt.Errorf("git fetch failed: %w", err)
@@ -179,8 +281,7 @@ func Fetch(dir string) error {
 }
 
 // RebaseOntoMain Rebase runs `git rebase origin/main`
-func RebaseOntoMain(dir string) error {
-	ctx := context.Background()
+func RebaseOntoMain(ctx context.Context, dir string) error {
 	_, err := RunCombined(ctx, dir, "rebase", "origin/main")
 	if err != nil {
 		return fmt.Errorf("git rebase failed: %w", err)
@@ -199,14 +300,11 @@ func RunGitWithOutput(ctx context.Context, args ...string) (<-chan string, <-cha
 		defer close(outCh)
 		defer close(errCh)
 
-		// apply default timeout if none given
-		if _, ok := ctx.Deadline(); !ok {
-			var cancel context.CancelFunc
-			ctx, cancel = context.WithTimeout(ctx, 8*time.Minute)
-			defer cancel()
-		}
+		ctx, cancel := WithTimeout(ctx, args)
+		defer cancel()
 
 		cmd := exec.CommandContext(ctx, "git", args...)
+		Graceful(cmd)
 
 		stdoutPipe, err := cmd.StdoutPipe()
 		if err != nil {
@@ -219,7 +317,9 @@ func RunGitWithOutput(ctx context.Context, args ...string) (<-chan string, <-cha
 			return
 		}
 
+		start := time.Now()
 		if err := cmd.Start(); err != nil {
+			ran(args, start, err)
 			errCh <- fmt.Errorf("start git: %w", err)
 			return
 		}
@@ -249,8 +349,10 @@ func RunGitWithOutput(ctx context.Context, args ...string) (<-chan string, <-cha
 		}()
 
 		// wait for command to finish
-		if err := cmd.Wait(); err != nil {
-			errCh <- fmt.Errorf("git failed: %w", err)
+		err = cmd.Wait()
+		ran(args, start, err)
+		if err != nil {
+			errCh <- timedOut(ctx, args, fmt.Errorf("git failed: %w", err))
 			return
 		}
 		errCh <- nil
@@ -261,8 +363,7 @@ func RunGitWithOutput(ctx context.Context, args ...string) (<-chan string, <-cha
 
 // IsDirty checks if there are any uncommitted changes in the repo.
 // Returns true if there are staged or unstaged changes.
-func IsDirty(dir string) (bool, error) {
-	ctx := context.Background()
+func IsDirty(ctx context.Context, dir string) (bool, error) {
 	out, err := RunCombined(ctx, dir, "status", "--porcelain")
 	if err != nil {
 		return false, err
@@ -0,0 +1,53 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package git
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCommandHook(t *testing.T) {
+	type call struct {
+		argv     []string
+		exitCode int
+	}
+	var calls []call
+	SetCommandHook(func(argv []string, d time.Duration, exitCode int) {
+		calls = append(calls, call{argv, exitCode})
+	})
+	t.Cleanup(func() { SetCommandHook(nil) })
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	if _, err := RunCombined(ctx, dir, "init", "-q"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RunCombined(ctx, dir, "rev-parse", "--verify", "--quiet", "nope"); err == nil {
+		t.Fatal("rev-parse of a missing ref succeeded")
+	}
+	if err := RunStream(ctx, dir, []string{"status", "--short"}, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RunInput(ctx, dir, "", "hash-object", "--stdin"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []call{
+		{[]string{"git", "init", "-q"}, 0},
+		{[]string{"git", "rev-parse", "--verify", "--quiet", "nope"}, 1},
+		{[]string{"git", "status", "--short"}, 0},
+		{[]string{"git", "hash-object", "--stdin"}, 0},
+	}
+	if !reflect.DeepEqual(calls, want) {
+		var got []string
+		for _, c := range calls {
+			got = append(got, strings.Join(c.argv, " "))
+		}
+		t.Errorf("hook got %v (%+v), want %+v", got, calls, want)
+	}
+}
@@ -0,0 +1,45 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// HookMarker identifies hook scripts written by GitMate so they can be updated safely.
+const HookMarker = "# installed by gitmate"
+
+// InstallHook writes script as the named hook unless a hook not written by GitMate
+// already exists, in which case it returns an error and leaves it untouched.
+func InstallHook(ctx context.Context, dir, name, script string) error {
+	hooksDir, err := RunCombined(ctx, dir, "rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return err
+	}
+	if !filepath.IsAbs(hooksDir) {
+		top, err := RunCombined(ctx, dir, "rev-parse", "--show-toplevel")
+		if err != nil {
+			return err
+		}
+		hooksDir = filepath.Join(top, hooksDir)
+	}
+	path := filepath.Join(hooksDir, name)
+
+	existing, err := os.ReadFile(path)
+	switch {
+	case err == nil && !strings.Contains(string(existing), HookMarker):
+		return fmt.Errorf("%s hook already exists at %s", name, path)
+	case err != nil && !errors.Is(err, os.ErrNotExist):
+		return err
+	}
+	if err := os.MkdirAll(hooksDir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(script), 0o755)
+}
@@ -0,0 +1,48 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package git
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Progress is one update of a phase git reports with --progress, e.g.
+// "Receiving objects:  45% (450/1000), 1.2 MiB | 2.3 MiB/s".
+type Progress struct {
+	Phase   string // e.g. "Receiving objects"; "remote: " is stripped
+	Percent int
+	Current int
+	Total   int
+	Detail  string // what follows the counts, e.g. "1.2 MiB | 2.3 MiB/s"
+	Done    bool   // the last update of the phase, e.g. ending in ", done."
+}
+
+var progressRe = regexp.MustCompile(`^(?:remote: )?([A-Z][A-Za-z ]+):\s+(\d+)% \((\d+)/(\d+)\)(.*)$`)
+
+// ParseProgress parses a line of git's progress output. git separates the
+// updates of a phase with carriage returns, so split on those as well as on
+// newlines before calling it.
+func ParseProgress(line string) (Progress, bool) {
+	m := progressRe.FindStringSubmatch(strings.TrimSpace(line))
+	if m == nil {
+		return Progress{}, false
+	}
+	p := Progress{Phase: m[1]}
+	p.Percent, _ = strconv.Atoi(m[2])
+	p.Current, _ = strconv.Atoi(m[3])
+	p.Total, _ = strconv.Atoi(m[4])
+	rest := strings.TrimSpace(m[5])
+	switch {
+	case strings.HasSuffix(rest, "done."):
+		p.Done = true
+		rest = strings.TrimSuffix(rest, "done.")
+	case strings.Contains(rest, "completed with"):
+		// "Resolving deltas: 100% (2/2), completed with 1 local object."
+		p.Done = true
+	}
+	p.Detail = strings.Trim(rest, " ,")
+	return p, true
+}
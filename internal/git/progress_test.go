@@ -0,0 +1,47 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package git
+
+import "testing"
+
+func TestParseProgress(t *testing.T) {
+	tests := []struct {
+		line string
+		want Progress
+		ok   bool
+	}{
+		{
+			"Receiving objects:  45% (450/1000), 1.2 MiB | 2.3 MiB/s",
+			Progress{Phase: "Receiving objects", Percent: 45, Current: 450, Total: 1000, Detail: "1.2 MiB | 2.3 MiB/s"},
+			true,
+		},
+		{
+			"Receiving objects: 100% (1000/1000), 4.5 MiB | 3 MiB/s, done.",
+			Progress{Phase: "Receiving objects", Percent: 100, Current: 1000, Total: 1000, Detail: "4.5 MiB | 3 MiB/s", Done: true},
+			true,
+		},
+		{
+			"remote: Counting objects: 100% (10/10), done.",
+			Progress{Phase: "Counting objects", Percent: 100, Current: 10, Total: 10, Done: true},
+			true,
+		},
+		{
+			"Resolving deltas: 100% (2/2), completed with 1 local object.",
+			Progress{Phase: "Resolving deltas", Percent: 100, Current: 2, Total: 2, Detail: "completed with 1 local object.", Done: true},
+			true,
+		},
+		{"  Writing objects:   0% (0/3)  ", Progress{Phase: "Writing objects", Total: 3}, true},
+		// counts without a percentage are no progress bar
+		{"remote: Enumerating objects: 10, done.", Progress{}, false},
+		{"Already up to date.", Progress{}, false},
+		{"From github.com:acme/app", Progress{}, false},
+		{"", Progress{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseProgress(tt.line)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("ParseProgress(%q) = %+v, %v; want %+v, %v", tt.line, got, ok, tt.want, tt.ok)
+		}
+	}
+}
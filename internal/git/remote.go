@@ -0,0 +1,97 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package git
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// RemoteURL returns the fetch URL of the named remote.
+func RemoteURL(ctx context.Context, dir, remote string) (string, error) {
+	return RunCombined(ctx, dir, "remote", "get-url", remote)
+}
+
+// ParseRemoteURL extracts the host and repository path ("owner/repo", or
+// "group/subgroup/repo" on GitLab) from https, ssh and scp-style remote URLs.
+func ParseRemoteURL(raw string) (host string, repoPath string, err error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", "", fmt.Errorf("empty remote URL")
+	}
+	if strings.Contains(raw, "://") {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return "", "", fmt.Errorf("parsing remote URL %q: %w", raw, err)
+		}
+		host, repoPath = u.Host, u.Path
+	} else {
+		// scp-like syntax: [user@]host:owner/repo.git
+		at := strings.Index(raw, "@")
+		colon := strings.Index(raw, ":")
+		if colon == -1 {
+			return "", "", fmt.Errorf("unrecognised remote URL %q", raw)
+		}
+		host, repoPath = raw[at+1:colon], raw[colon+1:]
+	}
+	// ssh URLs may carry a port (ssh://git@host:2222/...); the API lives on the bare host
+	if h, _, ok := strings.Cut(host, ":"); ok && strings.HasPrefix(raw, "ssh://") {
+		host = h
+	}
+	repoPath = strings.TrimSuffix(strings.Trim(repoPath, "/"), ".git")
+	if host == "" || !strings.Contains(repoPath, "/") {
+		return "", "", fmt.Errorf("unrecognised remote URL %q", raw)
+	}
+	return host, repoPath, nil
+}
+
+// RunInput runs `git <args...>` in dir with input on stdin. Terminal prompts are
+// disabled so helpers such as `git credential fill` fail instead of blocking.
+func RunInput(ctx context.Context, dir, input string, args ...string) (string, error) {
+	ctx, cancel := WithTimeout(ctx, args)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "git", args...)
+	if dir != "" {
+		cmd.Dir = dir
+	}
+	Graceful(cmd)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	cmd.Stdin = strings.NewReader(input)
+	var outBuf, errBuf bytes.Buffer
+	cmd.Stdout = &outBuf
+	cmd.Stderr = &errBuf
+	start := time.Now()
+	err := cmd.Run()
+	ran(args, start, err)
+	if err != nil {
+		if s := strings.TrimSpace(errBuf.String()); s != "" {
+			err = fmt.Errorf("%w: %s", err, s)
+		}
+		return "", timedOut(ctx, args, err)
+	}
+	return strings.TrimRight(outBuf.String(), "\n"), nil
+}
+
+// CredentialPassword asks the configured git credential helpers for the password
+// (token) stored for https://host. It returns "" when none is available.
+func CredentialPassword(ctx context.Context, dir, host string) string {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	out, err := RunInput(ctx, dir, "protocol=https\nhost="+host+"\n\n", "credential", "fill")
+	if err != nil {
+		return ""
+	}
+	for _, ln := range strings.Split(out, "\n") {
+		if v, ok := strings.CutPrefix(ln, "password="); ok {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,285 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package hosting
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// GitHubDefaultBaseURL is the public GitHub API. GitHub Enterprise uses https://<host>/api/v3.
+const GitHubDefaultBaseURL = "https://api.github.com"
+
+// GitHubBaseURLForHost returns the API base URL for a git remote host.
+func GitHubBaseURLForHost(host string) string {
+	if host == "" || host == "github.com" {
+		return GitHubDefaultBaseURL
+	}
+	return "https://" + host + "/api/v3"
+}
+
+// GitHub is the Provider for github.com and GitHub Enterprise.
+type GitHub struct {
+	api  apiClient
+	repo string // owner/name
+}
+
+// NewGitHub returns a GitHub provider for repo ("owner/name").
+func NewGitHub(baseURL, token, repo string) *GitHub {
+	if baseURL == "" {
+		baseURL = GitHubDefaultBaseURL
+	}
+	headers := map[string]string{
+		"Accept":               "application/vnd.github+json",
+		"X-GitHub-Api-Version": "2022-11-28",
+	}
+	if token != "" {
+		headers["Authorization"] = "Bearer " + token
+	}
+	return &GitHub{api: newAPIClient("github", baseURL, headers), repo: repo}
+}
+
+func (g *GitHub) Name() string       { return "GitHub" }
+func (g *GitHub) ReviewNoun() string { return "pull request" }
+
+// githubPull is the subset of the pull request resource GitMate uses.
+type githubPull struct {
+	Number  int    `json:"number"`
+	NodeID  string `json:"node_id"`
+	HTMLURL string `json:"html_url"`
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+	Draft   bool   `json:"draft"`
+	Head    struct {
+		Ref string `json:"ref"`
+		SHA string `json:"sha"`
+	} `json:"head"`
+	Base struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+	User struct {
+		Login string `json:"login"`
+	} `json:"user"`
+}
+
+func (p githubPull) review() *Review {
+	return &Review{
+		Number:  p.Number,
+		URL:     p.HTMLURL,
+		Title:   p.Title,
+		Body:    p.Body,
+		Draft:   p.Draft,
+		Head:    p.Head.Ref,
+		Base:    p.Base.Ref,
+		HeadSHA: p.Head.SHA,
+		Author:  p.User.Login,
+		nodeID:  p.NodeID,
+	}
+}
+
+func (g *GitHub) DefaultBranch(ctx context.Context) (string, error) {
+	var repo struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := g.api.do(ctx, http.MethodGet, "/repos/"+g.repo, nil, &repo); err != nil {
+		return "", err
+	}
+	return repo.DefaultBranch, nil
+}
+
+func (g *GitHub) FindReview(ctx context.Context, head string) (*Review, error) {
+	owner, _, _ := strings.Cut(g.repo, "/")
+	q := url.Values{"state": {"open"}, "head": {owner + ":" + head}}
+	var prs []githubPull
+	if err := g.api.do(ctx, http.MethodGet, "/repos/"+g.repo+"/pulls?"+q.Encode(), nil, &prs); err != nil {
+		return nil, err
+	}
+	if len(prs) == 0 {
+		return nil, nil
+	}
+	return prs[0].review(), nil
+}
+
+func (g *GitHub) ListOpenReviews(ctx context.Context) ([]Review, error) {
+	var prs []githubPull
+	if err := g.api.do(ctx, http.MethodGet, "/repos/"+g.repo+"/pulls?state=open&per_page=100", nil, &prs); err != nil {
+		return nil, err
+	}
+	res := make([]Review, 0, len(prs))
+	for _, p := range prs {
+		res = append(res, *p.review())
+	}
+	return res, nil
+}
+
+func (g *GitHub) CreateReview(ctx context.Context, in ReviewInput) (*Review, error) {
+	payload := map[string]any{
+		"title": in.Title,
+		"body":  in.Body,
+		"head":  in.Head,
+		"base":  in.Base,
+		"draft": in.Draft,
+	}
+	var pr githubPull
+	if err := g.api.do(ctx, http.MethodPost, "/repos/"+g.repo+"/pulls", payload, &pr); err != nil {
+		return nil, err
+	}
+	r := pr.review()
+	return r, g.decorate(ctx, r.Number, in)
+}
+
+func (g *GitHub) UpdateReview(ctx context.Context, number int, in ReviewInput) (*Review, error) {
+	payload := map[string]any{}
+	if in.Title != "" {
+		payload["title"] = in.Title
+	}
+	if in.Body != "" {
+		payload["body"] = in.Body
+	}
+	if in.Base != "" {
+		payload["base"] = in.Base
+	}
+	var pr githubPull
+	if err := g.api.do(ctx, http.MethodPatch, fmt.Sprintf("/repos/%s/pulls/%d", g.repo, number), payload, &pr); err != nil {
+		return nil, err
+	}
+	r := pr.review()
+	if err := g.setDraft(ctx, r, in.Draft); err != nil {
+		return r, err
+	}
+	return r, g.decorate(ctx, number, in)
+}
+
+// decorate requests reviewers and adds labels (pull requests are issues for labelling).
+func (g *GitHub) decorate(ctx context.Context, number int, in ReviewInput) error {
+	if len(in.Reviewers) > 0 {
+		payload := map[string][]string{"reviewers": in.Reviewers}
+		if err := g.api.do(ctx, http.MethodPost, fmt.Sprintf("/repos/%s/pulls/%d/requested_reviewers", g.repo, number), payload, nil); err != nil {
+			return fmt.Errorf("requesting reviewers: %w", err)
+		}
+	}
+	if len(in.Labels) > 0 {
+		payload := map[string][]string{"labels": in.Labels}
+		if err := g.api.do(ctx, http.MethodPost, fmt.Sprintf("/repos/%s/issues/%d/labels", g.repo, number), payload, nil); err != nil {
+			return fmt.Errorf("adding labels: %w", err)
+		}
+	}
+	return nil
+}
+
+// setDraft converts a pull request to a draft or marks it ready for review. The REST
+// API cannot change draft state, so this goes through the GraphQL endpoint.
+func (g *GitHub) setDraft(ctx context.Context, r *Review, draft bool) error {
+	if r.Draft == draft {
+		return nil
+	}
+	mutation := "markPullRequestReadyForReview"
+	if draft {
+		mutation = "convertPullRequestToDraft"
+	}
+	payload := map[string]any{
+		"query":     fmt.Sprintf("mutation($id: ID!) { %s(input: {pullRequestId: $id}) { clientMutationId } }", mutation),
+		"variables": map[string]string{"id": r.nodeID},
+	}
+	var out struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := g.api.do(ctx, http.MethodPost, g.graphQLURL(), payload, &out); err != nil {
+		return err
+	}
+	if len(out.Errors) > 0 {
+		return &APIError{Service: "github", StatusCode: http.StatusOK, Message: out.Errors[0].Message}
+	}
+	r.Draft = draft
+	return nil
+}
+
+// graphQLURL returns the GraphQL endpoint: /graphql on api.github.com and
+// /api/graphql on GitHub Enterprise (whose REST API lives under /api/v3).
+func (g *GitHub) graphQLURL() string {
+	if base, ok := strings.CutSuffix(g.api.baseURL, "/v3"); ok {
+		return base + "/graphql"
+	}
+	return g.api.baseURL + "/graphql"
+}
+
+// Checks merges legacy commit statuses and check runs for sha.
+func (g *GitHub) Checks(ctx context.Context, sha string) ([]Check, error) {
+	var combined struct {
+		Statuses []struct {
+			Context   string    `json:"context"`
+			State     string    `json:"state"`
+			TargetURL string    `json:"target_url"`
+			CreatedAt time.Time `json:"created_at"`
+			UpdatedAt time.Time `json:"updated_at"`
+		} `json:"statuses"`
+	}
+	if err := g.api.do(ctx, http.MethodGet, fmt.Sprintf("/repos/%s/commits/%s/status", g.repo, sha), nil, &combined); err != nil {
+		return nil, err
+	}
+	var runs struct {
+		CheckRuns []struct {
+			Name        string     `json:"name"`
+			Status      string     `json:"status"`
+			Conclusion  string     `json:"conclusion"`
+			HTMLURL     string     `json:"html_url"`
+			StartedAt   *time.Time `json:"started_at"`
+			CompletedAt *time.Time `json:"completed_at"`
+		} `json:"check_runs"`
+	}
+	if err := g.api.do(ctx, http.MethodGet, fmt.Sprintf("/repos/%s/commits/%s/check-runs?per_page=100", g.repo, sha), nil, &runs); err != nil {
+		return nil, err
+	}
+
+	var checks []Check
+	for _, s := range combined.Statuses {
+		c := Check{Name: s.Context, URL: s.TargetURL, StartedAt: s.CreatedAt, State: CheckPending}
+		switch s.State {
+		case "success":
+			c.State, c.CompletedAt = CheckSuccess, s.UpdatedAt
+		case "failure", "error":
+			c.State, c.CompletedAt = CheckFailure, s.UpdatedAt
+		}
+		checks = append(checks, c)
+	}
+	for _, r := range runs.CheckRuns {
+		c := Check{Name: r.Name, URL: r.HTMLURL, State: CheckPending}
+		if r.StartedAt != nil {
+			c.StartedAt = *r.StartedAt
+		}
+		if r.Status == "completed" {
+			if r.CompletedAt != nil {
+				c.CompletedAt = *r.CompletedAt
+			}
+			switch r.Conclusion {
+			case "success":
+				c.State = CheckSuccess
+			case "neutral", "skipped":
+				c.State = CheckSkipped
+			default:
+				c.State = CheckFailure
+			}
+		}
+		checks = append(checks, c)
+	}
+	return checks, nil
+}
+
+func (g *GitHub) Issue(ctx context.Context, number int) (*Issue, error) {
+	var issue struct {
+		Number  int    `json:"number"`
+		Title   string `json:"title"`
+		HTMLURL string `json:"html_url"`
+	}
+	if err := g.api.do(ctx, http.MethodGet, fmt.Sprintf("/repos/%s/issues/%d", g.repo, number), nil, &issue); err != nil {
+		return nil, err
+	}
+	return &Issue{Number: issue.Number, Title: issue.Title, URL: issue.HTMLURL}, nil
+}
@@ -0,0 +1,265 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package hosting
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const githubPullJSON = `{
+	"number": 7,
+	"node_id": "PR_kwDO7",
+	"html_url": "https://github.com/acme/app/pull/7",
+	"title": "Add login",
+	"body": "Closes #3",
+	"draft": %s,
+	"head": {"ref": "feature/login", "sha": "abc123"},
+	"base": {"ref": "main"},
+	"user": {"login": "ama"}
+}`
+
+// githubPullWith returns pull request #7 with the given draft state.
+func githubPullWith(draft bool) string {
+	return fmt.Sprintf(githubPullJSON, strconv.FormatBool(draft))
+}
+
+func TestGitHubFindReview(t *testing.T) {
+	api := newFakeAPI(t, map[string]reply{
+		"GET /repos/acme/app/pulls": {http.StatusOK, "[" + githubPullWith(false) + "]"},
+	})
+	g := NewGitHub(api.URL, "tok", "acme/app")
+
+	r, err := g.FindReview(context.Background(), "feature/login")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Review{
+		Number: 7, URL: "https://github.com/acme/app/pull/7", Title: "Add login", Body: "Closes #3",
+		Head: "feature/login", Base: "main", HeadSHA: "abc123", Author: "ama", nodeID: "PR_kwDO7",
+	}
+	if !reflect.DeepEqual(r, want) {
+		t.Errorf("FindReview = %+v, want %+v", r, want)
+	}
+
+	req := api.only(t, "GET /repos/acme/app/pulls")
+	if req.query != "head=acme%3Afeature%2Flogin&state=open" {
+		t.Errorf("query = %q, want the open pulls of acme:feature/login", req.query)
+	}
+	if got := req.header.Get("Authorization"); got != "Bearer tok" {
+		t.Errorf("Authorization = %q, want the token as bearer", got)
+	}
+}
+
+func TestGitHubFindReviewNone(t *testing.T) {
+	api := newFakeAPI(t, map[string]reply{
+		"GET /repos/acme/app/pulls": {http.StatusOK, "[]"},
+	})
+	r, err := NewGitHub(api.URL, "", "acme/app").FindReview(context.Background(), "feature/login")
+	if err != nil || r != nil {
+		t.Errorf("FindReview = %v, %v; want nil, nil", r, err)
+	}
+}
+
+func TestGitHubCreateReview(t *testing.T) {
+	api := newFakeAPI(t, map[string]reply{
+		"POST /repos/acme/app/pulls":                       {http.StatusCreated, githubPullWith(true)},
+		"POST /repos/acme/app/pulls/7/requested_reviewers": {http.StatusCreated, "{}"},
+		"POST /repos/acme/app/issues/7/labels":             {http.StatusOK, "[]"},
+	})
+	g := NewGitHub(api.URL, "tok", "acme/app")
+
+	r, err := g.CreateReview(context.Background(), ReviewInput{
+		Title: "Add login", Body: "Closes #3", Head: "feature/login", Base: "main", Draft: true,
+		Reviewers: []string{"kofi"}, Labels: []string{"auth"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Number != 7 || !r.Draft {
+		t.Errorf("CreateReview = %+v, want draft #7", r)
+	}
+
+	create := api.only(t, "POST /repos/acme/app/pulls")
+	want := map[string]any{"title": "Add login", "body": "Closes #3", "head": "feature/login", "base": "main", "draft": true}
+	if !reflect.DeepEqual(create.body, want) {
+		t.Errorf("create payload = %v, want %v", create.body, want)
+	}
+	reviewers := api.only(t, "POST /repos/acme/app/pulls/7/requested_reviewers")
+	if !reflect.DeepEqual(reviewers.body, map[string]any{"reviewers": []any{"kofi"}}) {
+		t.Errorf("reviewers payload = %v", reviewers.body)
+	}
+	labels := api.only(t, "POST /repos/acme/app/issues/7/labels")
+	if !reflect.DeepEqual(labels.body, map[string]any{"labels": []any{"auth"}}) {
+		t.Errorf("labels payload = %v", labels.body)
+	}
+}
+
+func TestGitHubUpdateReview(t *testing.T) {
+	api := newFakeAPI(t, map[string]reply{
+		"PATCH /repos/acme/app/pulls/7": {http.StatusOK, githubPullWith(false)},
+	})
+	g := NewGitHub(api.URL, "tok", "acme/app")
+
+	r, err := g.UpdateReview(context.Background(), 7, ReviewInput{Title: "Add login form", Base: "develop"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Number != 7 || r.Draft {
+		t.Errorf("UpdateReview = %+v, want ready #7", r)
+	}
+	update := api.only(t, "PATCH /repos/acme/app/pulls/7")
+	// an empty body is left as it is
+	want := map[string]any{"title": "Add login form", "base": "develop"}
+	if !reflect.DeepEqual(update.body, want) {
+		t.Errorf("update payload = %v, want %v", update.body, want)
+	}
+	if sent := api.sent("POST /graphql"); len(sent) != 0 {
+		t.Errorf("draft state was toggled although it didn't change")
+	}
+}
+
+func TestGitHubUpdateReviewTogglesDraft(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     string // API base path of the fake server
+		graphQL  string // where the GraphQL endpoint is expected
+		wasDraft bool
+		draft    bool
+		mutation string
+	}{
+		{"to draft", "", "/graphql", false, true, "convertPullRequestToDraft"},
+		{"ready for review", "", "/graphql", true, false, "markPullRequestReadyForReview"},
+		{"enterprise", "/api/v3", "/api/graphql", false, true, "convertPullRequestToDraft"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeAPI(t, map[string]reply{
+				"PATCH " + tt.base + "/repos/acme/app/pulls/7": {http.StatusOK, githubPullWith(tt.wasDraft)},
+				"POST " + tt.graphQL:                           {http.StatusOK, `{"data": {}}`},
+			})
+			g := NewGitHub(api.URL+tt.base, "tok", "acme/app")
+
+			r, err := g.UpdateReview(context.Background(), 7, ReviewInput{Draft: tt.draft})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r.Draft != tt.draft {
+				t.Errorf("Draft = %v, want %v", r.Draft, tt.draft)
+			}
+			mutation := api.only(t, "POST "+tt.graphQL)
+			if q, _ := mutation.body["query"].(string); !strings.Contains(q, tt.mutation+"(input: {pullRequestId: $id})") {
+				t.Errorf("query = %q, want %s", q, tt.mutation)
+			}
+			if vars := mutation.body["variables"]; !reflect.DeepEqual(vars, map[string]any{"id": "PR_kwDO7"}) {
+				t.Errorf("variables = %v, want the node id of #7", vars)
+			}
+		})
+	}
+}
+
+func TestGitHubUpdateReviewGraphQLError(t *testing.T) {
+	api := newFakeAPI(t, map[string]reply{
+		"PATCH /repos/acme/app/pulls/7": {http.StatusOK, githubPullWith(false)},
+		// GraphQL reports failures with 200 OK
+		"POST /graphql": {http.StatusOK, `{"errors": [{"message": "Resource not accessible by integration"}]}`},
+	})
+	g := NewGitHub(api.URL, "tok", "acme/app")
+
+	r, err := g.UpdateReview(context.Background(), 7, ReviewInput{Draft: true})
+	wantAPIError(t, err, "github", http.StatusOK, "Resource not accessible by integration")
+	if r == nil || r.Draft {
+		t.Errorf("review = %+v, want it returned still ready for review", r)
+	}
+}
+
+func TestGitHubErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		message string
+	}{
+		{"unauthorized", http.StatusUnauthorized, `{"message": "Bad credentials", "documentation_url": "https://docs.github.com/rest"}`, "Bad credentials"},
+		{"not found", http.StatusNotFound, `{"message": "Not Found"}`, "Not Found"},
+		{
+			"validation", http.StatusUnprocessableEntity,
+			`{"message": "Validation Failed", "errors": [{"resource": "PullRequest", "code": "custom", "message": "A pull request already exists for acme:feature/login."}]}`,
+			"Validation Failed: A pull request already exists for acme:feature/login.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeAPI(t, map[string]reply{
+				"POST /repos/acme/app/pulls": {tt.status, tt.body},
+			})
+			g := NewGitHub(api.URL, "tok", "acme/app")
+
+			r, err := g.CreateReview(context.Background(), ReviewInput{Title: "Add login", Head: "feature/login", Base: "main"})
+			if r != nil {
+				t.Errorf("CreateReview returned %+v with the error", r)
+			}
+			wantAPIError(t, err, "github", tt.status, tt.message)
+		})
+	}
+}
+
+func TestGitHubChecks(t *testing.T) {
+	api := newFakeAPI(t, map[string]reply{
+		"GET /repos/acme/app/commits/abc123/status": {http.StatusOK, `{"statuses": [
+			{"context": "ci/lint", "state": "success", "created_at": "2025-01-01T10:00:00Z", "updated_at": "2025-01-01T10:01:30Z"},
+			{"context": "ci/unit", "state": "failure"},
+			{"context": "ci/e2e", "state": "error"},
+			{"context": "ci/deploy", "state": "pending"}
+		]}`},
+		"GET /repos/acme/app/commits/abc123/check-runs": {http.StatusOK, `{"check_runs": [
+			{"name": "build", "status": "completed", "conclusion": "success"},
+			{"name": "optional", "status": "completed", "conclusion": "neutral"},
+			{"name": "docs", "status": "completed", "conclusion": "skipped"},
+			{"name": "test", "status": "completed", "conclusion": "failure"},
+			{"name": "flaky", "status": "completed", "conclusion": "cancelled"},
+			{"name": "slow", "status": "completed", "conclusion": "timed_out"},
+			{"name": "gate", "status": "completed", "conclusion": "action_required"},
+			{"name": "bench", "status": "in_progress"},
+			{"name": "release", "status": "queued"}
+		]}`},
+	})
+	g := NewGitHub(api.URL, "tok", "acme/app")
+
+	checks, err := g.Checks(context.Background(), "abc123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]CheckState{
+		"ci/lint":   CheckSuccess,
+		"ci/unit":   CheckFailure,
+		"ci/e2e":    CheckFailure,
+		"ci/deploy": CheckPending,
+		"build":     CheckSuccess,
+		"optional":  CheckSkipped,
+		"docs":      CheckSkipped,
+		"test":      CheckFailure,
+		"flaky":     CheckFailure,
+		"slow":      CheckFailure,
+		"gate":      CheckFailure,
+		"bench":     CheckPending,
+		"release":   CheckPending,
+	}
+	got := map[string]CheckState{}
+	for _, c := range checks {
+		got[c.Name] = c.State
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("states = %v, want %v", got, want)
+	}
+	if d := checks[0].Duration(); d != 90*time.Second {
+		t.Errorf("ci/lint ran %s, want 1m30s from its status times", d)
+	}
+}
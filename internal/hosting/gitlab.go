@@ -0,0 +1,235 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package hosting
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// GitLab is the Provider for gitlab.com and self-hosted GitLab.
+type GitLab struct {
+	api     apiClient
+	project string // URL-escaped "group/subgroup/name"
+}
+
+// NewGitLab returns a GitLab provider for the project at path ("group/name").
+func NewGitLab(baseURL, token, path string) *GitLab {
+	if baseURL == "" {
+		baseURL = "https://gitlab.com/api/v4"
+	}
+	headers := map[string]string{"Accept": "application/json"}
+	if token != "" {
+		headers["PRIVATE-TOKEN"] = token
+	}
+	return &GitLab{api: newAPIClient("gitlab", baseURL, headers), project: url.PathEscape(path)}
+}
+
+func (g *GitLab) Name() string       { return "GitLab" }
+func (g *GitLab) ReviewNoun() string { return "merge request" }
+
+// gitlabMR is the subset of the merge request resource GitMate uses.
+type gitlabMR struct {
+	IID          int    `json:"iid"`
+	WebURL       string `json:"web_url"`
+	Title        string `json:"title"`
+	Description  string `json:"description"`
+	Draft        bool   `json:"draft"`
+	SourceBranch string `json:"source_branch"`
+	TargetBranch string `json:"target_branch"`
+	SHA          string `json:"sha"`
+	Author       struct {
+		Username string `json:"username"`
+	} `json:"author"`
+}
+
+func (m gitlabMR) review() *Review {
+	return &Review{
+		Number:  m.IID,
+		URL:     m.WebURL,
+		Title:   m.Title,
+		Body:    m.Description,
+		Draft:   m.Draft,
+		Head:    m.SourceBranch,
+		Base:    m.TargetBranch,
+		HeadSHA: m.SHA,
+		Author:  m.Author.Username,
+	}
+}
+
+func (g *GitLab) path(format string, args ...any) string {
+	return "/projects/" + g.project + fmt.Sprintf(format, args...)
+}
+
+func (g *GitLab) DefaultBranch(ctx context.Context) (string, error) {
+	var p struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := g.api.do(ctx, http.MethodGet, g.path(""), nil, &p); err != nil {
+		return "", err
+	}
+	return p.DefaultBranch, nil
+}
+
+func (g *GitLab) FindReview(ctx context.Context, head string) (*Review, error) {
+	q := url.Values{"state": {"opened"}, "source_branch": {head}}
+	var mrs []gitlabMR
+	if err := g.api.do(ctx, http.MethodGet, g.path("/merge_requests?%s", q.Encode()), nil, &mrs); err != nil {
+		return nil, err
+	}
+	if len(mrs) == 0 {
+		return nil, nil
+	}
+	return mrs[0].review(), nil
+}
+
+func (g *GitLab) ListOpenReviews(ctx context.Context) ([]Review, error) {
+	var mrs []gitlabMR
+	if err := g.api.do(ctx, http.MethodGet, g.path("/merge_requests?state=opened&per_page=100"), nil, &mrs); err != nil {
+		return nil, err
+	}
+	res := make([]Review, 0, len(mrs))
+	for _, m := range mrs {
+		res = append(res, *m.review())
+	}
+	return res, nil
+}
+
+// draftTitle applies GitLab's draft convention, which lives in the title.
+func draftTitle(title string, draft bool) string {
+	bare := title
+	for _, prefix := range []string{"Draft: ", "Draft:", "WIP: ", "[Draft] "} {
+		bare = strings.TrimPrefix(bare, prefix)
+	}
+	if draft {
+		return "Draft: " + bare
+	}
+	return bare
+}
+
+func (g *GitLab) CreateReview(ctx context.Context, in ReviewInput) (*Review, error) {
+	payload := map[string]any{
+		"source_branch": in.Head,
+		"target_branch": in.Base,
+		"title":         draftTitle(in.Title, in.Draft),
+		"description":   in.Body,
+	}
+	if err := g.addPeopleAndLabels(ctx, payload, in, "labels"); err != nil {
+		return nil, err
+	}
+	var mr gitlabMR
+	if err := g.api.do(ctx, http.MethodPost, g.path("/merge_requests"), payload, &mr); err != nil {
+		return nil, err
+	}
+	return mr.review(), nil
+}
+
+func (g *GitLab) UpdateReview(ctx context.Context, number int, in ReviewInput) (*Review, error) {
+	payload := map[string]any{}
+	title := in.Title
+	if title == "" {
+		// the draft state lives in the title, so changing it takes the current one
+		var mr gitlabMR
+		if err := g.api.do(ctx, http.MethodGet, g.path("/merge_requests/%d", number), nil, &mr); err != nil {
+			return nil, err
+		}
+		if mr.Draft != in.Draft {
+			title = mr.Title
+		}
+	}
+	if title != "" {
+		payload["title"] = draftTitle(title, in.Draft)
+	}
+	if in.Body != "" {
+		payload["description"] = in.Body
+	}
+	if in.Base != "" {
+		payload["target_branch"] = in.Base
+	}
+	if err := g.addPeopleAndLabels(ctx, payload, in, "add_labels"); err != nil {
+		return nil, err
+	}
+	var mr gitlabMR
+	if err := g.api.do(ctx, http.MethodPut, g.path("/merge_requests/%d", number), payload, &mr); err != nil {
+		return nil, err
+	}
+	return mr.review(), nil
+}
+
+// addPeopleAndLabels resolves reviewer usernames to user ids (GitLab's API wants ids)
+// and adds labels under labelKey.
+func (g *GitLab) addPeopleAndLabels(ctx context.Context, payload map[string]any, in ReviewInput, labelKey string) error {
+	if len(in.Labels) > 0 {
+		payload[labelKey] = strings.Join(in.Labels, ",")
+	}
+	if len(in.Reviewers) == 0 {
+		return nil
+	}
+	var ids []int
+	for _, name := range in.Reviewers {
+		var users []struct {
+			ID int `json:"id"`
+		}
+		if err := g.api.do(ctx, http.MethodGet, "/users?username="+url.QueryEscape(name), nil, &users); err != nil {
+			return fmt.Errorf("looking up reviewer %s: %w", name, err)
+		}
+		if len(users) == 0 {
+			return fmt.Errorf("unknown GitLab user %s", name)
+		}
+		ids = append(ids, users[0].ID)
+	}
+	payload["reviewer_ids"] = ids
+	return nil
+}
+
+// Checks returns the pipeline job statuses reported for sha.
+func (g *GitLab) Checks(ctx context.Context, sha string) ([]Check, error) {
+	var statuses []struct {
+		Name       string     `json:"name"`
+		Status     string     `json:"status"`
+		TargetURL  string     `json:"target_url"`
+		CreatedAt  time.Time  `json:"created_at"`
+		StartedAt  *time.Time `json:"started_at"`
+		FinishedAt *time.Time `json:"finished_at"`
+	}
+	if err := g.api.do(ctx, http.MethodGet, g.path("/repository/commits/%s/statuses?per_page=100", sha), nil, &statuses); err != nil {
+		return nil, err
+	}
+	var checks []Check
+	for _, s := range statuses {
+		c := Check{Name: s.Name, URL: s.TargetURL, State: CheckPending, StartedAt: s.CreatedAt}
+		if s.StartedAt != nil {
+			c.StartedAt = *s.StartedAt
+		}
+		if s.FinishedAt != nil {
+			c.CompletedAt = *s.FinishedAt
+		}
+		switch s.Status {
+		case "success":
+			c.State = CheckSuccess
+		case "failed", "canceled":
+			c.State = CheckFailure
+		case "skipped", "manual":
+			c.State = CheckSkipped
+		}
+		checks = append(checks, c)
+	}
+	return checks, nil
+}
+
+func (g *GitLab) Issue(ctx context.Context, number int) (*Issue, error) {
+	var issue struct {
+		IID    int    `json:"iid"`
+		Title  string `json:"title"`
+		WebURL string `json:"web_url"`
+	}
+	if err := g.api.do(ctx, http.MethodGet, g.path("/issues/%d", number), nil, &issue); err != nil {
+		return nil, err
+	}
+	return &Issue{Number: issue.IID, Title: issue.Title, URL: issue.WebURL}, nil
+}
@@ -0,0 +1,261 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package hosting
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const gitlabMRJSON = `{
+	"iid": 4,
+	"web_url": "https://gitlab.com/acme/web/app/-/merge_requests/4",
+	"title": "Draft: Add login",
+	"description": "Closes #3",
+	"draft": true,
+	"source_branch": "feature/login",
+	"target_branch": "main",
+	"sha": "abc123",
+	"author": {"username": "ama"}
+}`
+
+// the project of the tests lives in a subgroup, so its id is acme%2Fweb%2Fapp
+const gitlabProject = "/projects/acme%2Fweb%2Fapp"
+
+func TestGitLabFindReview(t *testing.T) {
+	api := newFakeAPI(t, map[string]reply{
+		"GET " + gitlabProject + "/merge_requests": {http.StatusOK, "[" + gitlabMRJSON + "]"},
+	})
+	g := NewGitLab(api.URL, "tok", "acme/web/app")
+
+	r, err := g.FindReview(context.Background(), "feature/login")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Review{
+		Number: 4, URL: "https://gitlab.com/acme/web/app/-/merge_requests/4", Title: "Draft: Add login",
+		Body: "Closes #3", Draft: true, Head: "feature/login", Base: "main", HeadSHA: "abc123", Author: "ama",
+	}
+	if !reflect.DeepEqual(r, want) {
+		t.Errorf("FindReview = %+v, want %+v", r, want)
+	}
+
+	req := api.only(t, "GET "+gitlabProject+"/merge_requests")
+	if req.query != "source_branch=feature%2Flogin&state=opened" {
+		t.Errorf("query = %q, want the opened merge requests of feature/login", req.query)
+	}
+	if got := req.header.Get("PRIVATE-TOKEN"); got != "tok" {
+		t.Errorf("PRIVATE-TOKEN = %q, want the token", got)
+	}
+}
+
+func TestGitLabFindReviewNone(t *testing.T) {
+	api := newFakeAPI(t, map[string]reply{
+		"GET " + gitlabProject + "/merge_requests": {http.StatusOK, "[]"},
+	})
+	r, err := NewGitLab(api.URL, "", "acme/web/app").FindReview(context.Background(), "feature/login")
+	if err != nil || r != nil {
+		t.Errorf("FindReview = %v, %v; want nil, nil", r, err)
+	}
+}
+
+func TestGitLabCreateReview(t *testing.T) {
+	api := newFakeAPI(t, map[string]reply{
+		"GET /users": {http.StatusOK, `[{"id": 42}]`},
+		"POST " + gitlabProject + "/merge_requests": {http.StatusCreated, gitlabMRJSON},
+	})
+	g := NewGitLab(api.URL, "tok", "acme/web/app")
+
+	r, err := g.CreateReview(context.Background(), ReviewInput{
+		Title: "Add login", Body: "Closes #3", Head: "feature/login", Base: "main", Draft: true,
+		Reviewers: []string{"kofi"}, Labels: []string{"auth", "ui"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Number != 4 || !r.Draft {
+		t.Errorf("CreateReview = %+v, want draft !4", r)
+	}
+
+	if q := api.only(t, "GET /users").query; q != "username=kofi" {
+		t.Errorf("user lookup query = %q, want username=kofi", q)
+	}
+	create := api.only(t, "POST "+gitlabProject+"/merge_requests")
+	want := map[string]any{
+		"source_branch": "feature/login",
+		"target_branch": "main",
+		// GitLab keeps the draft state in the title
+		"title":        "Draft: Add login",
+		"description":  "Closes #3",
+		"labels":       "auth,ui",
+		"reviewer_ids": []any{42.0},
+	}
+	if !reflect.DeepEqual(create.body, want) {
+		t.Errorf("create payload = %v, want %v", create.body, want)
+	}
+}
+
+func TestGitLabCreateReviewUnknownReviewer(t *testing.T) {
+	api := newFakeAPI(t, map[string]reply{
+		"GET /users": {http.StatusOK, "[]"},
+	})
+	g := NewGitLab(api.URL, "tok", "acme/web/app")
+
+	_, err := g.CreateReview(context.Background(), ReviewInput{Title: "Add login", Head: "feature/login", Base: "main", Reviewers: []string{"nobody"}})
+	if err == nil || err.Error() != "unknown GitLab user nobody" {
+		t.Errorf("err = %v, want the unknown reviewer", err)
+	}
+	if sent := api.sent("POST " + gitlabProject + "/merge_requests"); len(sent) != 0 {
+		t.Errorf("the merge request was created without its reviewer")
+	}
+}
+
+func TestGitLabUpdateReview(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    ReviewInput
+		title string
+	}{
+		{"to draft", ReviewInput{Title: "Add login", Draft: true}, "Draft: Add login"},
+		{"ready for review", ReviewInput{Title: "Draft: Add login"}, "Add login"},
+		{"older prefix", ReviewInput{Title: "WIP: Add login"}, "Add login"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeAPI(t, map[string]reply{
+				"PUT " + gitlabProject + "/merge_requests/4": {http.StatusOK, gitlabMRJSON},
+			})
+			g := NewGitLab(api.URL, "tok", "acme/web/app")
+
+			if _, err := g.UpdateReview(context.Background(), 4, tt.in); err != nil {
+				t.Fatal(err)
+			}
+			update := api.only(t, "PUT "+gitlabProject+"/merge_requests/4")
+			if !reflect.DeepEqual(update.body, map[string]any{"title": tt.title}) {
+				t.Errorf("update payload = %v, want title %q", update.body, tt.title)
+			}
+		})
+	}
+}
+
+func TestGitLabUpdateReviewDraftWithoutTitle(t *testing.T) {
+	tests := []struct {
+		name    string
+		draft   bool
+		payload map[string]any
+	}{
+		// the current title carries the toggle
+		{"ready for review", false, map[string]any{"title": "Add login", "add_labels": "auth"}},
+		{"stays draft", true, map[string]any{"add_labels": "auth"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeAPI(t, map[string]reply{
+				"GET " + gitlabProject + "/merge_requests/4": {http.StatusOK, gitlabMRJSON},
+				"PUT " + gitlabProject + "/merge_requests/4": {http.StatusOK, gitlabMRJSON},
+			})
+			g := NewGitLab(api.URL, "tok", "acme/web/app")
+
+			if _, err := g.UpdateReview(context.Background(), 4, ReviewInput{Draft: tt.draft, Labels: []string{"auth"}}); err != nil {
+				t.Fatal(err)
+			}
+			update := api.only(t, "PUT "+gitlabProject+"/merge_requests/4")
+			if !reflect.DeepEqual(update.body, tt.payload) {
+				t.Errorf("update payload = %v, want %v", update.body, tt.payload)
+			}
+		})
+	}
+}
+
+func TestGitLabUpdateReviewLabels(t *testing.T) {
+	api := newFakeAPI(t, map[string]reply{
+		"GET " + gitlabProject + "/merge_requests/4": {http.StatusOK, gitlabMRJSON},
+		"PUT " + gitlabProject + "/merge_requests/4": {http.StatusOK, gitlabMRJSON},
+	})
+	g := NewGitLab(api.URL, "tok", "acme/web/app")
+
+	if _, err := g.UpdateReview(context.Background(), 4, ReviewInput{Base: "develop", Draft: true, Labels: []string{"auth"}}); err != nil {
+		t.Fatal(err)
+	}
+	update := api.only(t, "PUT "+gitlabProject+"/merge_requests/4")
+	// labels are added to the existing ones rather than replacing them
+	want := map[string]any{"target_branch": "develop", "add_labels": "auth"}
+	if !reflect.DeepEqual(update.body, want) {
+		t.Errorf("update payload = %v, want %v", update.body, want)
+	}
+}
+
+func TestGitLabErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		message string
+	}{
+		{"unauthorized", http.StatusUnauthorized, `{"message": "401 Unauthorized"}`, "401 Unauthorized"},
+		{"not found", http.StatusNotFound, `{"message": "404 Project Not Found"}`, "404 Project Not Found"},
+		{"validation", http.StatusUnprocessableEntity, `{"message": {"title": ["can't be blank"], "target_branch": ["is invalid"]}}`, "target_branch is invalid; title can't be blank"},
+		{"list", http.StatusConflict, `{"message": ["Another open merge request already exists for this source branch: !3"]}`, "Another open merge request already exists for this source branch: !3"},
+		{"oauth", http.StatusUnauthorized, `{"error": "invalid_token"}`, "invalid_token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeAPI(t, map[string]reply{
+				"POST " + gitlabProject + "/merge_requests": {tt.status, tt.body},
+			})
+			g := NewGitLab(api.URL, "tok", "acme/web/app")
+
+			r, err := g.CreateReview(context.Background(), ReviewInput{Title: "Add login", Head: "feature/login", Base: "main"})
+			if r != nil {
+				t.Errorf("CreateReview returned %+v with the error", r)
+			}
+			wantAPIError(t, err, "gitlab", tt.status, tt.message)
+		})
+	}
+}
+
+func TestGitLabChecks(t *testing.T) {
+	api := newFakeAPI(t, map[string]reply{
+		"GET " + gitlabProject + "/repository/commits/abc123/statuses": {http.StatusOK, `[
+			{"name": "lint", "status": "success", "created_at": "2025-01-01T09:59:00Z", "started_at": "2025-01-01T10:00:00Z", "finished_at": "2025-01-01T10:01:30Z"},
+			{"name": "unit", "status": "failed"},
+			{"name": "flaky", "status": "canceled"},
+			{"name": "docs", "status": "skipped"},
+			{"name": "deploy", "status": "manual"},
+			{"name": "e2e", "status": "running"},
+			{"name": "bench", "status": "pending"},
+			{"name": "release", "status": "created"}
+		]`},
+	})
+	g := NewGitLab(api.URL, "tok", "acme/web/app")
+
+	checks, err := g.Checks(context.Background(), "abc123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]CheckState{
+		"lint":    CheckSuccess,
+		"unit":    CheckFailure,
+		"flaky":   CheckFailure,
+		"docs":    CheckSkipped,
+		"deploy":  CheckSkipped,
+		"e2e":     CheckPending,
+		"bench":   CheckPending,
+		"release": CheckPending,
+	}
+	got := map[string]CheckState{}
+	for _, c := range checks {
+		got[c.Name] = c.State
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("states = %v, want %v", got, want)
+	}
+	// the job's start counts, not when it was queued
+	if d := checks[0].Duration(); d != 90*time.Second {
+		t.Errorf("lint ran %s, want 1m30s", d)
+	}
+}
@@ -0,0 +1,290 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+
+// Package hosting abstracts the code hosting services GitMate talks to (GitHub,
+// GitLab) behind a single Provider interface. The provider is detected from the
+// remote URL, and every API base URL is configurable so commands can be pointed
+// at self-hosted instances or an httptest stand-in.
+package hosting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/config"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/failure"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/git"
+)
+
+// Review is a pull request (GitHub) or merge request (GitLab).
+type Review struct {
+	Number  int    // PR number or MR iid
+	URL     string // web URL
+	Title   string
+	Body    string
+	Draft   bool
+	Head    string // source branch
+	Base    string // target branch
+	HeadSHA string
+	Author  string
+
+	nodeID string // GitHub GraphQL id, needed to toggle draft state
+}
+
+// ReviewInput describes a review to create or the new state of one being updated.
+type ReviewInput struct {
+	Title     string
+	Body      string
+	Head      string
+	Base      string
+	Draft     bool
+	Reviewers []string
+	Labels    []string
+}
+
+// Issue is an issue in the hosting service's tracker.
+type Issue struct {
+	Number int
+	Title  string
+	URL    string
+}
+
+// CheckState is the normalised state of a CI check.
+type CheckState string
+
+const (
+	CheckPending CheckState = "pending"
+	CheckSuccess CheckState = "success"
+	CheckFailure CheckState = "failure"
+	CheckSkipped CheckState = "skipped"
+)
+
+// Check is one CI status or check run reported for a commit.
+type Check struct {
+	Name        string
+	State       CheckState
+	URL         string
+	StartedAt   time.Time
+	CompletedAt time.Time
+}
+
+// Duration returns how long the check ran (so far, if still pending).
+func (c Check) Duration() time.Duration {
+	if c.StartedAt.IsZero() {
+		return 0
+	}
+	if c.CompletedAt.IsZero() {
+		return time.Since(c.StartedAt)
+	}
+	return c.CompletedAt.Sub(c.StartedAt)
+}
+
+// Provider is a code hosting service bound to one repository.
+type Provider interface {
+	// Name is the human readable service name ("GitHub").
+	Name() string
+	// ReviewNoun is what the service calls a review ("pull request").
+	ReviewNoun() string
+	// DefaultBranch returns the repository's default branch.
+	DefaultBranch(ctx context.Context) (string, error)
+	// FindReview returns the open review whose source branch is head, or nil.
+	FindReview(ctx context.Context, head string) (*Review, error)
+	// ListOpenReviews returns all open reviews in the repository.
+	ListOpenReviews(ctx context.Context) ([]Review, error)
+	// CreateReview opens a review and applies reviewers and labels.
+	CreateReview(ctx context.Context, in ReviewInput) (*Review, error)
+	// UpdateReview edits an existing review, including its draft state.
+	UpdateReview(ctx context.Context, number int, in ReviewInput) (*Review, error)
+	// Checks returns the CI statuses reported for commit sha.
+	Checks(ctx context.Context, sha string) ([]Check, error)
+	// Issue fetches an issue by number.
+	Issue(ctx context.Context, number int) (*Issue, error)
+}
+
+// Detect returns the provider for the given remote of the repository in dir. The
+// service is chosen from config.Hosting when set, otherwise from the remote host.
+func Detect(ctx context.Context, dir, remote string, cfg config.Config) (Provider, error) {
+	rawURL, err := git.RemoteURL(ctx, dir, remote)
+	if err != nil {
+		return nil, err
+	}
+	host, repo, err := git.ParseRemoteURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	kind := strings.ToLower(cfg.Hosting)
+	if kind == "" {
+		switch {
+		case strings.Contains(host, "gitlab"):
+			kind = "gitlab"
+		case strings.Contains(host, "github"):
+			kind = "github"
+		default:
+			return nil, fmt.Errorf("cannot tell which hosting service %s is; set `hosting: github` or `hosting: gitlab` in %s", host, config.FileName)
+		}
+	}
+
+	switch kind {
+	case "github":
+		baseURL := cfg.GitHub.APIURL
+		if baseURL == "" {
+			baseURL = GitHubBaseURLForHost(host)
+		}
+		return NewGitHub(baseURL, lookupToken(ctx, dir, host, cfg.GitHub.Token, "GITHUB_TOKEN", "GH_TOKEN"), repo), nil
+	case "gitlab":
+		baseURL := cfg.GitLab.APIURL
+		if baseURL == "" {
+			baseURL = "https://" + host + "/api/v4"
+		}
+		return NewGitLab(baseURL, lookupToken(ctx, dir, host, cfg.GitLab.Token, "GITLAB_TOKEN"), repo), nil
+	}
+	return nil, fmt.Errorf("unknown hosting service %q", cfg.Hosting)
+}
+
+// lookupToken returns the first token found in envVars, the git credential helper
+// for host, or the configured fallback.
+func lookupToken(ctx context.Context, dir, host, configured string, envVars ...string) string {
+	for _, env := range envVars {
+		if t := os.Getenv(env); t != "" {
+			return t
+		}
+	}
+	if t := git.CredentialPassword(ctx, dir, host); t != "" {
+		return t
+	}
+	return configured
+}
+
+// ---------------- HTTP ----------------
+
+// APIError is returned for non-2xx API responses.
+type APIError struct {
+	Service    string
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s: %d %s", e.Service, e.StatusCode, e.Message)
+}
+
+// apiClient is the JSON-over-HTTP plumbing shared by the providers.
+type apiClient struct {
+	service string
+	baseURL string
+	http    *http.Client
+	headers map[string]string
+}
+
+// requestTimeout bounds each API request. Zero means no limit.
+var requestTimeout = 30 * time.Second
+
+// SetTimeout replaces the timeout of all following API requests.
+func SetTimeout(d time.Duration) { requestTimeout = d }
+
+func newAPIClient(service, baseURL string, headers map[string]string) apiClient {
+	return apiClient{
+		service: service,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		http:    &http.Client{Timeout: requestTimeout},
+		headers: headers,
+	}
+}
+
+// do sends a JSON request to path (relative to the base URL, or absolute) and
+// decodes a JSON response into out (if non-nil).
+func (c apiClient) do(ctx context.Context, method, path string, in any, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	target := path
+	if !strings.HasPrefix(path, "http") {
+		target = c.baseURL + path
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return err
+	}
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		var ne net.Error
+		if errors.As(err, &ne) && ne.Timeout() {
+			return failure.Errorf(failure.Timeout, "%s API timed out (pass a longer --timeout): %w", c.service, err)
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var apiErr struct {
+			Message any    `json:"message"`
+			Error   string `json:"error"`
+			Errors  []struct {
+				Message string `json:"message"`
+			} `json:"errors"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&apiErr)
+		msg := apiErr.Error
+		if apiErr.Message != nil {
+			msg = apiMessage(apiErr.Message)
+		}
+		for _, e := range apiErr.Errors {
+			if e.Message != "" {
+				msg += ": " + e.Message
+			}
+		}
+		return &APIError{Service: c.service, StatusCode: resp.StatusCode, Message: msg}
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// apiMessage flattens the message of an error response. GitLab reports
+// validation failures as a list or as an object of field errors, e.g.
+// {"title": ["can't be blank"]}.
+func apiMessage(m any) string {
+	switch m := m.(type) {
+	case string:
+		return m
+	case []any:
+		parts := make([]string, 0, len(m))
+		for _, v := range m {
+			parts = append(parts, apiMessage(v))
+		}
+		return strings.Join(parts, "; ")
+	case map[string]any:
+		parts := make([]string, 0, len(m))
+		for _, k := range slices.Sorted(maps.Keys(m)) {
+			parts = append(parts, k+" "+apiMessage(m[k]))
+		}
+		return strings.Join(parts, "; ")
+	}
+	return fmt.Sprint(m)
+}
@@ -0,0 +1,103 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package hosting
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// ---------------- Fake API ----------------
+
+// reply is the canned response of a route of the fake API.
+type reply struct {
+	status int
+	body   string
+}
+
+// request is a request the fake API received.
+type request struct {
+	method string
+	path   string // escaped, e.g. /projects/acme%2Fapp
+	query  string
+	header http.Header
+	body   map[string]any
+}
+
+// fakeAPI answers "METHOD /escaped/path" routes with canned replies and records
+// every request it gets.
+type fakeAPI struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []request
+}
+
+func newFakeAPI(t *testing.T, routes map[string]reply) *fakeAPI {
+	t.Helper()
+	f := &fakeAPI{}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := request{method: r.Method, path: r.URL.EscapedPath(), query: r.URL.RawQuery, header: r.Header}
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			if err := json.Unmarshal(data, &req.body); err != nil {
+				t.Errorf("%s %s: body is no JSON object: %s", r.Method, req.path, data)
+			}
+		}
+		f.mu.Lock()
+		f.requests = append(f.requests, req)
+		f.mu.Unlock()
+
+		route, ok := routes[r.Method+" "+req.path]
+		if !ok {
+			t.Errorf("unexpected request %s %s", r.Method, req.path)
+			route = reply{http.StatusNotFound, `{"message":"Not Found"}`}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(route.status)
+		_, _ = io.WriteString(w, route.body)
+	}))
+	t.Cleanup(f.Close)
+	return f
+}
+
+// sent returns the requests made to "METHOD /escaped/path", in order.
+func (f *fakeAPI) sent(route string) []request {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var res []request
+	for _, r := range f.requests {
+		if r.method+" "+r.path == route {
+			res = append(res, r)
+		}
+	}
+	return res
+}
+
+// only returns the one request made to route.
+func (f *fakeAPI) only(t *testing.T, route string) request {
+	t.Helper()
+	sent := f.sent(route)
+	if len(sent) != 1 {
+		t.Fatalf("%s was requested %d times, want once", route, len(sent))
+	}
+	return sent[0]
+}
+
+// ---------------- Errors ----------------
+
+// wantAPIError checks err is an *APIError with the given status and message.
+func wantAPIError(t *testing.T, err error, service string, status int, message string) {
+	t.Helper()
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v, want an *APIError", err)
+	}
+	if apiErr.Service != service || apiErr.StatusCode != status || apiErr.Message != message {
+		t.Errorf("err = %+v, want %s %d %q", *apiErr, service, status, message)
+	}
+}
@@ -0,0 +1,286 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+
+// Package lesson loads the declarative lessons `gitmate tutor` runs. Lessons are
+// YAML files, or Markdown files with YAML front matter, either embedded in the
+// binary or read from user lesson directories.
+package lesson
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/sandbox"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed lessons
+var builtin embed.FS
+
+// Lesson is a sequence of steps teaching one workflow.
+type Lesson struct {
+	ID      string   `yaml:"id"`
+	Title   string   `yaml:"title"`
+	Summary string   `yaml:"summary"`
+	Level   string   `yaml:"level"`  // beginner, intermediate or advanced
+	Skills  []string `yaml:"skills"` // skills the lesson exercises
+	Mode    string   `yaml:"mode"`   // "" for a guided walkthrough, or "challenge"
+	Intro   string   `yaml:"intro"`  // shown before the first step
+	// Setup scripts the practice repository the lesson runs in.
+	Setup []sandbox.Action `yaml:"setup"`
+	Steps []Step           `yaml:"steps"`
+
+	Source string `yaml:"-"` // "builtin" or the file it was loaded from
+}
+
+// ModeChallenge lessons state a goal and check the repository only when the
+// learner asks, scoring failed checks and hints.
+const ModeChallenge = "challenge"
+
+// Challenge reports whether the lesson is a scored challenge.
+func (l Lesson) Challenge() bool {
+	return l.Mode == ModeChallenge
+}
+
+// NeedsRepo reports whether the lesson works on a practice repository; a lesson
+// made only of questions doesn't.
+func (l Lesson) NeedsRepo() bool {
+	if len(l.Setup) > 0 {
+		return true
+	}
+	for _, s := range l.Steps {
+		if s.Question == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Step is one thing for the learner to do.
+type Step struct {
+	Title    string    `yaml:"title"`
+	Explain  string    `yaml:"explain"`  // what and why, in Markdown
+	Commands []string  `yaml:"commands"` // what the learner is expected to run
+	Verify   []Check   `yaml:"verify"`   // all must hold for the step to complete
+	Hints    []string  `yaml:"hints"`    // revealed one at a time, most general first
+	Question *Question `yaml:"question"` // makes the step a multiple-choice question
+}
+
+// Manual reports whether the step has no checks and is completed by the learner.
+func (s Step) Manual() bool {
+	return len(s.Verify) == 0 && s.Question == nil
+}
+
+// Question is a multiple-choice concept question.
+type Question struct {
+	Prompt  string   `yaml:"prompt"`
+	Choices []string `yaml:"choices"`
+	Answer  int      `yaml:"answer"`  // 1-based index of the correct choice
+	Explain string   `yaml:"explain"` // shown once answered correctly
+}
+
+// ---------------- Loading ----------------
+
+// Builtin returns the lessons shipped with GitMate.
+func Builtin() ([]Lesson, error) {
+	sub, err := fs.Sub(builtin, "lessons")
+	if err != nil {
+		return nil, err
+	}
+	return LoadFS(sub, "builtin")
+}
+
+// UserDir is the per-user lesson directory, loaded in addition to configured ones.
+func UserDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gitmate", "lessons"), nil
+}
+
+// LoadDir loads the lessons in dir. A missing directory has no lessons.
+func LoadDir(dir string) ([]Lesson, error) {
+	if _, err := os.Stat(dir); errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	lessons, err := LoadFS(os.DirFS(dir), dir)
+	if err != nil {
+		return nil, fmt.Errorf("loading lessons from %s: %w", dir, err)
+	}
+	for i := range lessons {
+		lessons[i].Source = filepath.Join(dir, lessons[i].Source)
+	}
+	return lessons, nil
+}
+
+// LoadFS loads every .yml, .yaml and .md lesson at the top level of fsys, ordered
+// by file name. source labels builtin lessons; other lessons record their file.
+func LoadFS(fsys fs.FS, source string) ([]Lesson, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	var lessons []Lesson
+	for _, e := range entries {
+		ext := path.Ext(e.Name())
+		if e.IsDir() || (ext != ".yml" && ext != ".yaml" && ext != ".md") {
+			continue
+		}
+		data, err := fs.ReadFile(fsys, e.Name())
+		if err != nil {
+			return nil, err
+		}
+		l, err := Parse(data, ext == ".md")
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", e.Name(), err)
+		}
+		l.Source = source
+		if source != "builtin" {
+			l.Source = e.Name()
+		}
+		lessons = append(lessons, l)
+	}
+	return lessons, nil
+}
+
+// All returns the builtin lessons followed by those in dirs. A lesson with the
+// same id as an earlier one replaces it, so teams can override builtin content.
+func All(dirs ...string) ([]Lesson, error) {
+	lessons, err := Builtin()
+	if err != nil {
+		return nil, err
+	}
+	index := map[string]int{}
+	for i, l := range lessons {
+		index[l.ID] = i
+	}
+	for _, dir := range dirs {
+		extra, err := LoadDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range extra {
+			if i, ok := index[l.ID]; ok {
+				lessons[i] = l
+				continue
+			}
+			index[l.ID] = len(lessons)
+			lessons = append(lessons, l)
+		}
+	}
+	return lessons, nil
+}
+
+// Find returns the lesson with the given id.
+func Find(lessons []Lesson, id string) (Lesson, bool) {
+	for _, l := range lessons {
+		if l.ID == id {
+			return l, true
+		}
+	}
+	return Lesson{}, false
+}
+
+// ---------------- Parsing ----------------
+
+var headingRe = regexp.MustCompile(`(?m)^## +(.+)$`)
+
+// Parse decodes a lesson. Markdown lessons carry their metadata and steps in YAML
+// front matter; the body's "## " sections become the explanations of the steps,
+// in order, and any text before the first section becomes the intro.
+func Parse(data []byte, markdown bool) (Lesson, error) {
+	var l Lesson
+	body := ""
+	if markdown {
+		front, rest, ok := splitFrontMatter(data)
+		if !ok {
+			return l, fmt.Errorf("markdown lesson has no --- front matter")
+		}
+		data, body = front, rest
+	}
+	// unknown keys are usually a misspelt check, which would otherwise pass silently
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&l); err != nil {
+		return l, err
+	}
+	if markdown {
+		applyMarkdown(&l, body)
+	}
+	return l, l.validate()
+}
+
+func splitFrontMatter(data []byte) ([]byte, string, bool) {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	if !strings.HasPrefix(text, "---\n") {
+		return nil, "", false
+	}
+	front, rest, ok := strings.Cut(text[4:], "\n---\n")
+	if !ok {
+		return nil, "", false
+	}
+	return []byte(front), rest, true
+}
+
+func applyMarkdown(l *Lesson, body string) {
+	locs := headingRe.FindAllStringSubmatchIndex(body, -1)
+	intro := body
+	if len(locs) > 0 {
+		intro = body[:locs[0][0]]
+	}
+	if l.Intro == "" {
+		l.Intro = strings.TrimSpace(intro)
+	}
+	for i, loc := range locs {
+		if i >= len(l.Steps) {
+			break
+		}
+		end := len(body)
+		if i+1 < len(locs) {
+			end = locs[i+1][0]
+		}
+		if l.Steps[i].Title == "" {
+			l.Steps[i].Title = body[loc[2]:loc[3]]
+		}
+		if l.Steps[i].Explain == "" {
+			l.Steps[i].Explain = strings.TrimSpace(body[loc[1]:end])
+		}
+	}
+}
+
+func (l Lesson) validate() error {
+	var errs []error
+	if l.ID == "" {
+		errs = append(errs, fmt.Errorf("lesson has no id"))
+	}
+	if len(l.Steps) == 0 {
+		errs = append(errs, fmt.Errorf("lesson %s has no steps", l.ID))
+	}
+	if l.Mode != "" && l.Mode != ModeChallenge {
+		errs = append(errs, fmt.Errorf("unknown mode %q", l.Mode))
+	}
+	for i, s := range l.Steps {
+		if q := s.Question; q != nil && (len(q.Choices) < 2 || len(q.Choices) > 9 || q.Answer < 1 || q.Answer > len(q.Choices)) {
+			errs = append(errs, fmt.Errorf("step %d: question needs 2-9 choices and an answer between 1 and the number of choices", i+1))
+		}
+		for _, c := range s.Verify {
+			if err := c.validate(); err != nil {
+				errs = append(errs, fmt.Errorf("step %d: %w", i+1, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,120 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package lesson
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	const markdown = `---
+id: branches
+title: Branches
+steps:
+  - commands: [git switch -c feature]
+    verify:
+      - branch: ^feature$
+  - title: Kept
+    explain: kept too
+---
+Why branches matter.
+
+## Make a branch
+Run the command.
+
+## Ignored heading
+ignored body
+`
+	tests := []struct {
+		name     string
+		data     string
+		markdown bool
+		errHas   string // "" when the lesson is valid
+		check    func(t *testing.T, l Lesson)
+	}{
+		{
+			name: "yaml",
+			data: "id: sync\nmode: challenge\nsteps:\n  - title: Fetch\n    verify:\n      - behind: {of: origin/main, max: 0}\n",
+			check: func(t *testing.T, l Lesson) {
+				if l.ID != "sync" || !l.Challenge() || len(l.Steps) != 1 || l.Steps[0].Verify[0].Behind.Of != "origin/main" {
+					t.Errorf("lesson = %+v", l)
+				}
+			},
+		},
+		{
+			name:     "markdown",
+			data:     markdown,
+			markdown: true,
+			check: func(t *testing.T, l Lesson) {
+				if l.Intro != "Why branches matter." {
+					t.Errorf("intro = %q", l.Intro)
+				}
+				if s := l.Steps[0]; s.Title != "Make a branch" || s.Explain != "Run the command." {
+					t.Errorf("step 1 = %q: %q", s.Title, s.Explain)
+				}
+				// front matter wins over the body
+				if s := l.Steps[1]; s.Title != "Kept" || s.Explain != "kept too" {
+					t.Errorf("step 2 = %q: %q", s.Title, s.Explain)
+				}
+			},
+		},
+		{
+			name:     "markdown with CRLF",
+			data:     strings.ReplaceAll(markdown, "\n", "\r\n"),
+			markdown: true,
+			check: func(t *testing.T, l Lesson) {
+				if l.ID != "branches" || l.Steps[0].Title != "Make a branch" {
+					t.Errorf("lesson = %+v", l)
+				}
+			},
+		},
+		{name: "no front matter", data: "## Step\n", markdown: true, errHas: "no --- front matter"},
+		{name: "unclosed front matter", data: "---\nid: x\n", markdown: true, errHas: "no --- front matter"},
+		{name: "misspelt check", data: "id: x\nsteps:\n  - verify:\n      - brnach: main\n", errHas: "field brnach not found"},
+		{name: "no id", data: "steps:\n  - title: x\n", errHas: "lesson has no id"},
+		{name: "no steps", data: "id: x\n", errHas: "lesson x has no steps"},
+		{name: "unknown mode", data: "id: x\nmode: exam\nsteps:\n  - title: x\n", errHas: `unknown mode "exam"`},
+		{
+			name:   "answer out of range",
+			data:   "id: x\nsteps:\n  - question: {prompt: p, choices: [a, b], answer: 3}\n",
+			errHas: "step 1: question needs 2-9 choices",
+		},
+		{name: "one choice", data: "id: x\nsteps:\n  - question: {prompt: p, choices: [a], answer: 1}\n", errHas: "question needs 2-9 choices"},
+		{name: "bad pattern", data: "id: x\nsteps:\n  - verify:\n      - headMessage: '('\n", errHas: "step 1: error parsing regexp"},
+		{name: "contains without ref", data: "id: x\nsteps:\n  - verify:\n      - contains: {message: fix}\n", errHas: "contains needs `ref`"},
+		{name: "ahead without of", data: "id: x\nsteps:\n  - verify:\n      - ahead: {min: 1}\n", errHas: "ahead/behind needs `of`"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := Parse([]byte(tt.data), tt.markdown)
+			switch {
+			case tt.errHas == "" && err != nil:
+				t.Fatalf("Parse = %v", err)
+			case tt.errHas != "" && (err == nil || !strings.Contains(err.Error(), tt.errHas)):
+				t.Fatalf("Parse = %v, want an error containing %q", err, tt.errHas)
+			}
+			if tt.check != nil {
+				tt.check(t, l)
+			}
+		})
+	}
+}
+
+func TestBuiltin(t *testing.T) {
+	lessons, err := Builtin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := map[string]bool{}
+	for _, l := range lessons {
+		if seen[l.ID] {
+			t.Errorf("lesson id %s is used twice", l.ID)
+		}
+		seen[l.ID] = true
+		if l.Source != "builtin" {
+			t.Errorf("%s: source = %q", l.ID, l.Source)
+		}
+	}
+}
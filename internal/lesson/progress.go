@@ -0,0 +1,125 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package lesson
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Progress is the learner's record across lessons, stored as JSON in the user
+// config dir.
+type Progress struct {
+	Lessons map[string]*LessonProgress `json:"lessons"`
+
+	path string
+}
+
+// LessonProgress is the record for one lesson.
+type LessonProgress struct {
+	Completed    bool       `json:"completed"` // finished at least once
+	CompletedAt  *time.Time `json:"completedAt,omitempty"`
+	Steps        []bool     `json:"steps"` // completion of each step in the current attempt
+	Attempts     int        `json:"attempts"`
+	HintsUsed    int        `json:"hintsUsed"`
+	BestScore    int        `json:"bestScore"` // out of 100; 0 until completed
+	TimeSpent    int64      `json:"timeSpentSeconds"`
+	StartedAt    time.Time  `json:"startedAt"`
+	LastActivity time.Time  `json:"lastActivity"`
+	Sandbox      string     `json:"sandbox,omitempty"` // kept practice repository to resume in
+}
+
+// StepsDone counts the completed steps of the current attempt.
+func (lp *LessonProgress) StepsDone() int {
+	n := 0
+	for _, done := range lp.Steps {
+		if done {
+			n++
+		}
+	}
+	return n
+}
+
+// InProgress reports whether the learner started the lesson and hasn't finished
+// the current attempt.
+func (lp *LessonProgress) InProgress() bool {
+	return lp.Attempts > 0 && lp.StepsDone() < len(lp.Steps)
+}
+
+// ProgressPath returns where progress is stored.
+func ProgressPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gitmate", "progress.json"), nil
+}
+
+// LoadProgress reads the learner's progress; a missing file is empty progress.
+func LoadProgress() (*Progress, error) {
+	path, err := ProgressPath()
+	if err != nil {
+		return nil, err
+	}
+	p := &Progress{Lessons: map[string]*LessonProgress{}, path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return p, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, err
+	}
+	if p.Lessons == nil {
+		p.Lessons = map[string]*LessonProgress{}
+	}
+	return p, nil
+}
+
+// Save writes the progress back, replacing the file atomically.
+func (p *Progress) Save() error {
+	if err := os.MkdirAll(filepath.Dir(p.path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := p.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, p.path)
+}
+
+// Lesson returns the record for l, creating it. The step list is resized if the
+// lesson's steps changed since it was saved.
+func (p *Progress) Lesson(l Lesson) *LessonProgress {
+	lp, ok := p.Lessons[l.ID]
+	if !ok {
+		lp = &LessonProgress{}
+		p.Lessons[l.ID] = lp
+	}
+	if len(lp.Steps) != len(l.Steps) {
+		lp.Steps = make([]bool, len(l.Steps))
+	}
+	return lp
+}
+
+// LastInProgress returns the id of the most recently active unfinished lesson.
+func (p *Progress) LastInProgress() string {
+	id := ""
+	var last time.Time
+	for k, lp := range p.Lessons {
+		if lp.InProgress() && lp.LastActivity.After(last) {
+			id, last = k, lp.LastActivity
+		}
+	}
+	return id
+}
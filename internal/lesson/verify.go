@@ -0,0 +1,253 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package lesson
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/git"
+)
+
+// Check is a condition on repository state. Every field that is set must hold;
+// a step usually lists one condition per check for clearer feedback.
+type Check struct {
+	Branch       string     `yaml:"branch"`       // current branch matches this regular expression
+	BranchExists string     `yaml:"branchExists"` // a local branch with this name exists
+	BranchGone   string     `yaml:"branchGone"`   // no local branch with this name exists
+	Clean        *bool      `yaml:"clean"`        // the working tree is (true) or isn't (false) clean
+	Ahead        *RefCount  `yaml:"ahead"`        // commits on HEAD that are not on a ref
+	Behind       *RefCount  `yaml:"behind"`       // commits on a ref that are not on HEAD
+	HeadMessage  string     `yaml:"headMessage"`  // HEAD's subject matches this regular expression
+	FileContains *FileMatch `yaml:"fileContains"` // a working tree file matches a regular expression
+	FileLacks    *FileMatch `yaml:"fileLacks"`    // a working tree file does not match a regular expression
+	Contains     *RefCommit `yaml:"contains"`     // a ref's history has a commit with a matching subject
+	Stashes      *int       `yaml:"stashes"`      // exact number of stash entries
+	InProgress   *bool      `yaml:"inProgress"`   // a merge, rebase or cherry-pick is (or isn't) underway
+}
+
+// RefCount bounds a commit count relative to a ref.
+type RefCount struct {
+	Of  string `yaml:"of"`
+	Min *int   `yaml:"min"`
+	Max *int   `yaml:"max"`
+}
+
+// RefCommit looks for a commit in a ref's history.
+type RefCommit struct {
+	Ref     string `yaml:"ref"`
+	Message string `yaml:"message"` // regular expression matched against subjects
+}
+
+// FileMatch checks a file's contents.
+type FileMatch struct {
+	Path    string `yaml:"path"`
+	Pattern string `yaml:"pattern"`
+}
+
+func (c Check) validate() error {
+	for _, re := range []string{c.Branch, c.HeadMessage} {
+		if _, err := regexp.Compile(re); err != nil {
+			return err
+		}
+	}
+	for _, fm := range []*FileMatch{c.FileContains, c.FileLacks} {
+		if fm == nil {
+			continue
+		}
+		if _, err := regexp.Compile(fm.Pattern); err != nil {
+			return err
+		}
+	}
+	if c.Contains != nil {
+		if _, err := regexp.Compile(c.Contains.Message); err != nil {
+			return err
+		}
+		if c.Contains.Ref == "" {
+			return fmt.Errorf("contains needs `ref`")
+		}
+	}
+	for _, rc := range []*RefCount{c.Ahead, c.Behind} {
+		if rc != nil && rc.Of == "" {
+			return fmt.Errorf("ahead/behind needs `of`")
+		}
+	}
+	return nil
+}
+
+// ---------------- Verifier ----------------
+
+// Verifier evaluates checks against the repository in Dir. Vars are substituted
+// for {name} placeholders in check values, e.g. {trunk}.
+type Verifier struct {
+	Dir  string
+	Vars map[string]string
+}
+
+// Expand substitutes Vars into s; lesson text uses the same placeholders.
+func (v Verifier) Expand(s string) string {
+	for k, val := range v.Vars {
+		s = strings.ReplaceAll(s, "{"+k+"}", val)
+	}
+	return s
+}
+
+// pattern expands the placeholders of the regular expression re; the values
+// are matched literally.
+func (v Verifier) pattern(re string) (*regexp.Regexp, error) {
+	for k, val := range v.Vars {
+		re = strings.ReplaceAll(re, "{"+k+"}", regexp.QuoteMeta(val))
+	}
+	return regexp.Compile(re)
+}
+
+// match reports whether the pattern re matches s; an invalid pattern fails the
+// check with the reason.
+func (v Verifier) match(re, s string) (bool, string) {
+	r, err := v.pattern(re)
+	if err != nil {
+		return false, fmt.Sprintf("invalid pattern %q: %v", re, err)
+	}
+	return r.MatchString(s), ""
+}
+
+// Step reports whether every check of s holds. When one doesn't, detail says
+// what is still missing.
+func (v Verifier) Step(ctx context.Context, s Step) (ok bool, detail string) {
+	for _, c := range s.Verify {
+		if ok, detail := v.Check(ctx, c); !ok {
+			return false, detail
+		}
+	}
+	return true, ""
+}
+
+// Check evaluates a single check.
+func (v Verifier) Check(ctx context.Context, c Check) (bool, string) {
+	if c.Branch != "" {
+		cur, _ := git.CurrentBranch(ctx, v.Dir)
+		if ok, invalid := v.match(c.Branch, cur); !ok {
+			return false, cmp.Or(invalid, fmt.Sprintf("current branch is %q", cur))
+		}
+	}
+	if c.BranchExists != "" {
+		name := v.Expand(c.BranchExists)
+		if !git.RefExists(ctx, v.Dir, "refs/heads/"+name) {
+			return false, fmt.Sprintf("branch %s does not exist", name)
+		}
+	}
+	if c.BranchGone != "" {
+		name := v.Expand(c.BranchGone)
+		if git.RefExists(ctx, v.Dir, "refs/heads/"+name) {
+			return false, fmt.Sprintf("branch %s still exists", name)
+		}
+	}
+	if c.Clean != nil {
+		status, err := git.GitStatusPorcelain(ctx, v.Dir)
+		if err != nil {
+			return false, err.Error()
+		}
+		if clean := len(status) == 0; clean != *c.Clean {
+			if clean {
+				return false, "there are no uncommitted changes"
+			}
+			return false, fmt.Sprintf("%d files have uncommitted changes", len(status))
+		}
+	}
+	if c.Ahead != nil {
+		of := v.Expand(c.Ahead.Of)
+		ahead, _, err := git.AheadBehind(ctx, v.Dir, of, "HEAD")
+		if err != nil {
+			return false, fmt.Sprintf("cannot compare with %s", of)
+		}
+		if ok, detail := c.Ahead.within(ahead, "ahead of "+of); !ok {
+			return false, detail
+		}
+	}
+	if c.Behind != nil {
+		of := v.Expand(c.Behind.Of)
+		_, behind, err := git.AheadBehind(ctx, v.Dir, of, "HEAD")
+		if err != nil {
+			return false, fmt.Sprintf("cannot compare with %s", of)
+		}
+		if ok, detail := c.Behind.within(behind, "behind "+of); !ok {
+			return false, detail
+		}
+	}
+	if c.HeadMessage != "" {
+		subject, _ := git.RunCombined(ctx, v.Dir, "log", "-1", "--format=%s")
+		if ok, invalid := v.match(c.HeadMessage, subject); !ok {
+			return false, cmp.Or(invalid, fmt.Sprintf("last commit is %q", subject))
+		}
+	}
+	if c.FileContains != nil {
+		p := v.Expand(c.FileContains.Path)
+		data, err := os.ReadFile(filepath.Join(v.Dir, p))
+		if err != nil {
+			return false, fmt.Sprintf("cannot read %s", p)
+		}
+		if ok, invalid := v.match(c.FileContains.Pattern, string(data)); !ok {
+			return false, cmp.Or(invalid, fmt.Sprintf("%s does not contain the expected text yet", p))
+		}
+	}
+	if c.FileLacks != nil {
+		p := v.Expand(c.FileLacks.Path)
+		data, err := os.ReadFile(filepath.Join(v.Dir, p))
+		if err != nil {
+			return false, fmt.Sprintf("cannot read %s", p)
+		}
+		if ok, invalid := v.match(c.FileLacks.Pattern, string(data)); ok || invalid != "" {
+			return false, cmp.Or(invalid, fmt.Sprintf("%s still contains text that should be gone", p))
+		}
+	}
+	if c.Contains != nil {
+		ref := v.Expand(c.Contains.Ref)
+		out, err := git.RunCombined(ctx, v.Dir, "log", "--format=%s", ref, "--")
+		if err != nil {
+			return false, fmt.Sprintf("%s does not exist", ref)
+		}
+		if ok, invalid := v.match("(?m)"+c.Contains.Message, out); !ok {
+			return false, cmp.Or(invalid, fmt.Sprintf("%s has no commit matching %q", ref, c.Contains.Message))
+		}
+	}
+	if c.Stashes != nil {
+		out, _ := git.RunCombined(ctx, v.Dir, "stash", "list")
+		n := 0
+		if out != "" {
+			n = len(strings.Split(out, "\n"))
+		}
+		if n != *c.Stashes {
+			return false, fmt.Sprintf("%d stash entries", n)
+		}
+	}
+	if c.InProgress != nil {
+		op := git.OperationInProgress(ctx, v.Dir)
+		if (op != "") != *c.InProgress {
+			if op != "" {
+				return false, "a " + op + " is still in progress"
+			}
+			return false, "no merge, rebase or cherry-pick is in progress"
+		}
+	}
+	return true, ""
+}
+
+func (rc RefCount) within(n int, relation string) (bool, string) {
+	noun := "commits"
+	if n == 1 {
+		noun = "commit"
+	}
+	if rc.Min != nil && n < *rc.Min {
+		return false, fmt.Sprintf("%d %s %s (want at least %d)", n, noun, relation, *rc.Min)
+	}
+	if rc.Max != nil && n > *rc.Max {
+		return false, fmt.Sprintf("%d %s %s (want at most %d)", n, noun, relation, *rc.Max)
+	}
+	return true, ""
+}
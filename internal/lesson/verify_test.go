@@ -0,0 +1,102 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package lesson
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/sandbox"
+)
+
+// verifyRepo builds a sandbox on feature/login, one commit ahead of main and
+// one behind origin/main, with a stash entry and an untracked file.
+func verifyRepo(t *testing.T) string {
+	t.Helper()
+	t.Setenv("TMPDIR", t.TempDir())
+	ctx := context.Background()
+	s, err := sandbox.New(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Remove() })
+	err = s.Apply(ctx, []sandbox.Action{
+		{Commit: &sandbox.CommitSpec{Message: "Initial commit", Files: map[string]string{"README.md": "hello\nTODO\n"}}},
+		{Push: "main"},
+		{Teammate: &sandbox.CommitSpec{Message: "Teammate fix", Files: map[string]string{"fix.txt": "fixed\n"}}},
+		{Git: []string{"fetch", "--quiet", "origin"}},
+		{Branch: "feature/login"},
+		{Commit: &sandbox.CommitSpec{Message: "Add login", Files: map[string]string{"login.go": "func Login() {}\n"}}},
+		{Write: map[string]string{"README.md": "changed\n"}},
+		{Git: []string{"stash", "--quiet"}},
+		{Write: map[string]string{"scratch.txt": "notes\n"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s.Work
+}
+
+func TestVerifierCheck(t *testing.T) {
+	yes, no := true, false
+	zero, one := 0, 1
+	tests := []struct {
+		name      string
+		check     Check
+		detailHas string // "" when the check holds
+	}{
+		{name: "branch", check: Check{Branch: "^feature/{name}$"}},
+		{name: "other branch", check: Check{Branch: "^{trunk}$"}, detailHas: `current branch is "feature/login"`},
+		// placeholder values are literal text, not patterns
+		{name: "quoted placeholder", check: Check{Branch: "^{any}$"}, detailHas: "current branch is"},
+		{name: "invalid pattern", check: Check{HeadMessage: "("}, detailHas: `invalid pattern "("`},
+		{name: "branch exists", check: Check{BranchExists: "feature/{name}"}},
+		{name: "branch missing", check: Check{BranchExists: "nope"}, detailHas: "branch nope does not exist"},
+		{name: "branch gone", check: Check{BranchGone: "nope"}},
+		{name: "branch not gone", check: Check{BranchGone: "{trunk}"}, detailHas: "branch main still exists"},
+		{name: "dirty", check: Check{Clean: &no}},
+		{name: "not clean", check: Check{Clean: &yes}, detailHas: "1 files have uncommitted changes"},
+		{name: "ahead", check: Check{Ahead: &RefCount{Of: "{trunk}", Min: &one}}},
+		{name: "too far ahead", check: Check{Ahead: &RefCount{Of: "{trunk}", Max: &zero}}, detailHas: "1 commit ahead of main (want at most 0)"},
+		{name: "behind", check: Check{Behind: &RefCount{Of: "origin/{trunk}", Min: &one, Max: &one}}},
+		{name: "behind unknown ref", check: Check{Behind: &RefCount{Of: "nope"}}, detailHas: "cannot compare with nope"},
+		{name: "head message", check: Check{HeadMessage: "^Add {name}$"}},
+		{name: "other head message", check: Check{HeadMessage: "^Fix"}, detailHas: `last commit is "Add login"`},
+		{name: "file contains", check: Check{FileContains: &FileMatch{Path: "{name}.go", Pattern: `func Login\(`}}},
+		{name: "file lacks", check: Check{FileContains: &FileMatch{Path: "README.md", Pattern: "changed"}}, detailHas: "README.md does not contain the expected text yet"},
+		{name: "missing file", check: Check{FileContains: &FileMatch{Path: "missing.txt", Pattern: "x"}}, detailHas: "cannot read missing.txt"},
+		{name: "lacks", check: Check{FileLacks: &FileMatch{Path: "README.md", Pattern: "changed"}}},
+		{name: "still contains", check: Check{FileLacks: &FileMatch{Path: "README.md", Pattern: "TODO"}}, detailHas: "README.md still contains text"},
+		{name: "contains", check: Check{Contains: &RefCommit{Ref: "origin/{trunk}", Message: "^Teammate fix$"}}},
+		{name: "lacks commit", check: Check{Contains: &RefCommit{Ref: "{trunk}", Message: "^Teammate"}}, detailHas: `main has no commit matching "^Teammate"`},
+		{name: "contains unknown ref", check: Check{Contains: &RefCommit{Ref: "nope", Message: "x"}}, detailHas: "nope does not exist"},
+		{name: "stashes", check: Check{Stashes: &one}},
+		{name: "wrong stashes", check: Check{Stashes: &zero}, detailHas: "1 stash entries"},
+		{name: "nothing in progress", check: Check{InProgress: &no}},
+		{name: "expected in progress", check: Check{InProgress: &yes}, detailHas: "no merge, rebase or cherry-pick is in progress"},
+	}
+	ctx := context.Background()
+	v := Verifier{
+		Dir:  verifyRepo(t),
+		Vars: map[string]string{"trunk": "main", "name": "login", "any": ".*"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, detail := v.Check(ctx, tt.check)
+			switch {
+			case tt.detailHas == "" && !ok:
+				t.Errorf("Check = false (%s), want true", detail)
+			case tt.detailHas != "" && (ok || !strings.Contains(detail, tt.detailHas)):
+				t.Errorf("Check = %v (%s), want false with %q", ok, detail, tt.detailHas)
+			}
+		})
+	}
+
+	// a step reports the first check that doesn't hold
+	step := Step{Verify: []Check{{BranchExists: "{trunk}"}, {BranchGone: "{trunk}"}, {BranchExists: "nope"}}}
+	if ok, detail := v.Step(ctx, step); ok || detail != "branch main still exists" {
+		t.Errorf("Step = %v (%s), want the branchGone failure", ok, detail)
+	}
+}
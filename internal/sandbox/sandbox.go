@@ -0,0 +1,215 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+
+// Package sandbox builds throwaway practice repositories: a bare "origin", the
+// learner's clone, and a teammate's clone used to push commits the learner has to
+// integrate. Lessons describe the starting history as a list of Actions.
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/git"
+)
+
+// Trunk is the default branch of every sandbox.
+const Trunk = "main"
+
+// identities used for scripted commits, so history reads like a real team's
+var (
+	authorIdentity   = []string{"-c", "user.name=Ada Lovelace", "-c", "user.email=ada@example.com"}
+	teammateIdentity = []string{"-c", "user.name=Sam Teammate", "-c", "user.email=sam@example.com"}
+)
+
+// Sandbox is a practice setup under Root.
+type Sandbox struct {
+	Root     string // temp directory holding everything below
+	Origin   string // bare repository acting as the remote
+	Work     string // the learner's clone
+	Teammate string // a second clone; created on first use
+}
+
+// New creates an empty sandbox: a bare origin and a clone of it, both on Trunk.
+func New(ctx context.Context) (*Sandbox, error) {
+	root, err := os.MkdirTemp("", "gitmate-sandbox-")
+	if err != nil {
+		return nil, err
+	}
+	s := &Sandbox{
+		Root:   root,
+		Origin: filepath.Join(root, "origin.git"),
+		Work:   filepath.Join(root, "work"),
+	}
+	steps := [][]string{
+		{"init", "--quiet", "--bare", "--initial-branch=" + Trunk, s.Origin},
+		{"clone", "--quiet", s.Origin, s.Work},
+	}
+	for _, args := range steps {
+		if _, err := git.RunCombined(ctx, root, args...); err != nil {
+			s.Remove()
+			return nil, err
+		}
+	}
+	// an empty clone doesn't know the remote's default branch yet
+	if _, err := git.RunCombined(ctx, s.Work, "symbolic-ref", "HEAD", "refs/heads/"+Trunk); err != nil {
+		s.Remove()
+		return nil, err
+	}
+	// learners without a global identity could not commit at all
+	if email, _ := git.RunCombined(ctx, s.Work, "config", "user.email"); email == "" {
+		_, _ = git.RunCombined(ctx, s.Work, "config", "user.name", "GitMate Learner")
+		_, _ = git.RunCombined(ctx, s.Work, "config", "user.email", "learner@example.com")
+	}
+	return s, nil
+}
+
+// Open returns the sandbox previously created at root, e.g. to resume a lesson.
+func Open(root string) (*Sandbox, error) {
+	s := &Sandbox{
+		Root:   root,
+		Origin: filepath.Join(root, "origin.git"),
+		Work:   filepath.Join(root, "work"),
+	}
+	if _, err := os.Stat(s.Work); err != nil {
+		return nil, err
+	}
+	if teammate := filepath.Join(root, "teammate"); dirExists(teammate) {
+		s.Teammate = teammate
+	}
+	return s, nil
+}
+
+func dirExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// Remove deletes the sandbox.
+func (s *Sandbox) Remove() error {
+	return os.RemoveAll(s.Root)
+}
+
+// ---------------- Actions ----------------
+
+// Action is one scripted setup step. Exactly one field should be set.
+type Action struct {
+	Commit   *CommitSpec       `yaml:"commit"`   // commit files in the learner's clone
+	Teammate *CommitSpec       `yaml:"teammate"` // commit in the teammate's clone and push it
+	Branch   string            `yaml:"branch"`   // create a branch at HEAD and switch to it
+	Switch   string            `yaml:"switch"`   // switch to an existing branch
+	Push     string            `yaml:"push"`     // push a branch to origin and track it
+	Write    map[string]string `yaml:"write"`    // leave uncommitted file changes
+	Git      []string          `yaml:"git"`      // run any git command in the learner's clone
+}
+
+// CommitSpec describes a scripted commit.
+type CommitSpec struct {
+	Message string            `yaml:"message"`
+	Files   map[string]string `yaml:"files"`  // path -> new content
+	Delete  []string          `yaml:"delete"` // paths to remove
+	Branch  string            `yaml:"branch"` // teammate commits only: branch to commit to (default main)
+}
+
+// Apply runs the actions in order.
+func (s *Sandbox) Apply(ctx context.Context, actions []Action) error {
+	for i, a := range actions {
+		if err := s.apply(ctx, a); err != nil {
+			return fmt.Errorf("setup step %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+func (s *Sandbox) apply(ctx context.Context, a Action) error {
+	switch {
+	case a.Commit != nil:
+		return s.commit(ctx, s.Work, authorIdentity, *a.Commit)
+	case a.Teammate != nil:
+		return s.teammateCommit(ctx, *a.Teammate)
+	case a.Branch != "":
+		return s.git(ctx, s.Work, "switch", "--quiet", "-c", a.Branch)
+	case a.Switch != "":
+		return s.git(ctx, s.Work, "switch", "--quiet", a.Switch)
+	case a.Push != "":
+		return s.git(ctx, s.Work, "push", "--quiet", "-u", "origin", a.Push)
+	case len(a.Write) > 0:
+		return writeFiles(s.Work, a.Write)
+	case len(a.Git) > 0:
+		return s.git(ctx, s.Work, append(append([]string{}, authorIdentity...), a.Git...)...)
+	}
+	return fmt.Errorf("empty action")
+}
+
+func (s *Sandbox) commit(ctx context.Context, dir string, identity []string, c CommitSpec) error {
+	if err := writeFiles(dir, c.Files); err != nil {
+		return err
+	}
+	for _, p := range c.Delete {
+		if err := s.git(ctx, dir, "rm", "--quiet", "--", p); err != nil {
+			return err
+		}
+	}
+	if err := s.git(ctx, dir, "add", "--all"); err != nil {
+		return err
+	}
+	args := append(append([]string{}, identity...), "commit", "--quiet", "--allow-empty", "-m", c.Message)
+	return s.git(ctx, dir, args...)
+}
+
+// teammateCommit commits on the teammate's clone and pushes, so origin moves ahead
+// of the learner's remote-tracking branches until they fetch.
+func (s *Sandbox) teammateCommit(ctx context.Context, c CommitSpec) error {
+	if s.Teammate == "" {
+		s.Teammate = filepath.Join(s.Root, "teammate")
+		if err := s.git(ctx, s.Root, "clone", "--quiet", s.Origin, s.Teammate); err != nil {
+			return err
+		}
+	}
+	branch := c.Branch
+	if branch == "" {
+		branch = Trunk
+	}
+	if err := s.git(ctx, s.Teammate, "fetch", "--quiet", "origin"); err != nil {
+		return err
+	}
+	if err := s.git(ctx, s.Teammate, "switch", "--quiet", "-C", branch, "origin/"+branch); err != nil {
+		return err
+	}
+	if err := s.commit(ctx, s.Teammate, teammateIdentity, c); err != nil {
+		return err
+	}
+	return s.git(ctx, s.Teammate, "push", "--quiet", "origin", branch)
+}
+
+func (s *Sandbox) git(ctx context.Context, dir string, args ...string) error {
+	_, err := git.RunCombined(ctx, dir, args...)
+	return err
+}
+
+// writeFiles writes files under dir in a stable order, creating directories.
+func writeFiles(dir string, files map[string]string) error {
+	paths := make([]string, 0, len(files))
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		if strings.Contains(p, "..") {
+			return fmt.Errorf("path %s leaves the sandbox", p)
+		}
+		full := filepath.Join(dir, filepath.FromSlash(p))
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(full, []byte(files[p]), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package sandbox
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/git"
+)
+
+func newSandbox(t *testing.T) *Sandbox {
+	t.Helper()
+	t.Setenv("TMPDIR", t.TempDir())
+	s, err := New(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Remove() })
+	return s
+}
+
+// log returns the subjects of ref in dir, newest first.
+func log(t *testing.T, dir, ref string) string {
+	t.Helper()
+	out, err := git.RunCombined(context.Background(), dir, "log", "--format=%s", ref, "--")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.ReplaceAll(out, "\n", " | ")
+}
+
+func TestApply(t *testing.T) {
+	ctx := context.Background()
+	s := newSandbox(t)
+	// the clone is empty, so ask for the branch HEAD is born on
+	if head, _ := git.RunCombined(ctx, s.Work, "symbolic-ref", "--short", "HEAD"); head != Trunk {
+		t.Errorf("new sandbox is on %q, want %s", head, Trunk)
+	}
+
+	err := s.Apply(ctx, []Action{
+		{Commit: &CommitSpec{Message: "Initial commit", Files: map[string]string{"README.md": "hello\n", "docs/old.md": "old\n"}}},
+		{Push: Trunk},
+		{Branch: "feature"},
+		{Commit: &CommitSpec{Message: "Drop old docs", Delete: []string{"docs/old.md"}}},
+		{Push: "feature"},
+		{Switch: Trunk},
+		{Teammate: &CommitSpec{Message: "Teammate change", Files: map[string]string{"team.txt": "x\n"}}},
+		{Teammate: &CommitSpec{Message: "Teammate review", Branch: "feature"}},
+		{Git: []string{"fetch", "--quiet", "origin"}},
+		{Write: map[string]string{"notes/todo.txt": "later\n"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		dir, ref, want string
+	}{
+		{s.Work, Trunk, "Initial commit"},
+		{s.Work, "feature", "Drop old docs | Initial commit"},
+		// the teammate's commits reach the learner only through origin
+		{s.Work, "origin/" + Trunk, "Teammate change | Initial commit"},
+		{s.Work, "origin/feature", "Teammate review | Drop old docs | Initial commit"},
+	}
+	for _, tt := range tests {
+		if got := log(t, tt.dir, tt.ref); got != tt.want {
+			t.Errorf("log %s = %q, want %q", tt.ref, got, tt.want)
+		}
+	}
+	if author, _ := git.RunCombined(ctx, s.Origin, "log", "-1", "--format=%an", "feature"); author != "Sam Teammate" {
+		t.Errorf("teammate commits are by %q", author)
+	}
+	if _, err := os.Stat(filepath.Join(s.Work, "docs", "old.md")); err != nil {
+		t.Errorf("old.md is gone from %s: %v", Trunk, err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(s.Work, "notes", "todo.txt")); string(data) != "later\n" {
+		t.Errorf("todo.txt = %q", data)
+	}
+}
+
+func TestApplyErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		action Action
+		errHas string
+	}{
+		{"empty", Action{}, "setup step 1: empty action"},
+		{"outside the sandbox", Action{Write: map[string]string{"../escape.txt": "x"}}, "path ../escape.txt leaves the sandbox"},
+		{"unknown branch", Action{Switch: "nope"}, "setup step 1:"},
+	}
+	s := newSandbox(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.Apply(context.Background(), []Action{tt.action})
+			if err == nil || !strings.Contains(err.Error(), tt.errHas) {
+				t.Errorf("Apply = %v, want an error containing %q", err, tt.errHas)
+			}
+		})
+	}
+	if _, err := os.Stat(filepath.Join(s.Root, "escape.txt")); err == nil {
+		t.Error("escape.txt was written outside the clone")
+	}
+}
+
+func TestOpen(t *testing.T) {
+	ctx := context.Background()
+	s := newSandbox(t)
+	if o, err := Open(s.Root); err != nil || o.Work != s.Work || o.Teammate != "" {
+		t.Fatalf("Open = %+v, %v", o, err)
+	}
+	// resuming keeps using the teammate's clone once there is one
+	if err := s.Apply(ctx, []Action{{Commit: &CommitSpec{Message: "first"}}, {Push: Trunk}, {Teammate: &CommitSpec{Message: "second"}}}); err != nil {
+		t.Fatal(err)
+	}
+	o, err := Open(s.Root)
+	if err != nil || o.Teammate != s.Teammate {
+		t.Fatalf("Open = %+v, %v; want teammate %s", o, err, s.Teammate)
+	}
+	if err := o.Apply(ctx, []Action{{Teammate: &CommitSpec{Message: "third"}}}); err != nil {
+		t.Fatal(err)
+	}
+	if got := log(t, s.Origin, Trunk); got != "third | second | first" {
+		t.Errorf("origin log = %q", got)
+	}
+
+	if err := o.Remove(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(s.Root); err == nil {
+		t.Error("Open of a removed sandbox succeeded")
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package tracker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/failure"
+)
+
+// Jira looks up issues through the Jira REST API (v2, supported by Cloud and Server).
+type Jira struct {
+	BaseURL string
+	User    string
+	Token   string
+	HTTP    *http.Client
+}
+
+// requestTimeout bounds each Jira request. Zero means no limit.
+var requestTimeout = 10 * time.Second
+
+// SetTimeout replaces the timeout of all following Jira requests.
+func SetTimeout(d time.Duration) { requestTimeout = d }
+
+// NewJira returns a Jira tracker. With a user it authenticates with basic auth
+// (Jira Cloud API tokens); otherwise the token is sent as a bearer PAT.
+func NewJira(baseURL, user, token string) *Jira {
+	return &Jira{
+		BaseURL: strings.TrimRight(baseURL, "/"),
+		User:    user,
+		Token:   token,
+		HTTP:    &http.Client{Timeout: requestTimeout},
+	}
+}
+
+func (j *Jira) Issue(ctx context.Context, key string) (*Issue, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, j.BaseURL+"/rest/api/2/issue/"+key+"?fields=summary", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	switch {
+	case j.User != "":
+		req.SetBasicAuth(j.User, j.Token)
+	case j.Token != "":
+		req.Header.Set("Authorization", "Bearer "+j.Token)
+	}
+
+	resp, err := j.HTTP.Do(req)
+	if err != nil {
+		var ne net.Error
+		if errors.As(err, &ne) && ne.Timeout() {
+			return nil, failure.Errorf(failure.Timeout, "jira timed out (pass a longer --timeout): %w", err)
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("jira: %s for %s", resp.Status, key)
+	}
+	var out struct {
+		Key    string `json:"key"`
+		Fields struct {
+			Summary string `json:"summary"`
+		} `json:"fields"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+	return &Issue{Key: out.Key, Title: out.Fields.Summary, URL: j.BaseURL + "/browse/" + out.Key}, nil
+}
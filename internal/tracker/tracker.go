@@ -0,0 +1,154 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+
+// Package tracker resolves issue keys (PROJ-123, #45) to issues in Jira or in the
+// hosting service's own issue tracker, and links them to branches.
+package tracker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/config"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/git"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/hosting"
+)
+
+// Branch config keys linking a branch to its issue.
+const (
+	KeyConfig = "gitmateIssue"
+	URLConfig = "gitmateIssueURL"
+)
+
+// Issue is a tracker issue.
+type Issue struct {
+	Key   string // PROJ-123 or #45
+	Title string
+	URL   string
+}
+
+// Tracker looks up issues by key.
+type Tracker interface {
+	Issue(ctx context.Context, key string) (*Issue, error)
+}
+
+var (
+	jiraKeyRe = regexp.MustCompile(`^[A-Z][A-Z0-9]+-\d+$`)
+	hashKeyRe = regexp.MustCompile(`^#\d+$`)
+)
+
+// ParseKey reports whether s is an issue key. PROJ-123 style keys only count
+// when Jira is configured and, with jira.projects set, PROJ is one of them, so
+// branch names like release-2 stay branch names.
+func ParseKey(s string, cfg config.Config) (string, bool) {
+	s = strings.TrimSpace(s)
+	if hashKeyRe.MatchString(s) {
+		return s, true
+	}
+	if !jiraKeyRe.MatchString(s) {
+		return "", false
+	}
+	project, _, _ := strings.Cut(s, "-")
+	if len(cfg.Jira.Projects) > 0 {
+		return s, slices.Contains(cfg.Jira.Projects, project)
+	}
+	return s, cfg.Jira.URL != ""
+}
+
+// ForKey returns the tracker responsible for key: Jira for PROJ-123 style keys,
+// the hosting service for #45.
+func ForKey(ctx context.Context, dir, key string, cfg config.Config) (Tracker, error) {
+	if strings.HasPrefix(key, "#") {
+		p, err := hosting.Detect(ctx, dir, "origin", cfg)
+		if err != nil {
+			return nil, err
+		}
+		return hostingTracker{p}, nil
+	}
+	if cfg.Jira.URL == "" {
+		return nil, fmt.Errorf("no Jira URL configured (jira.url in %s)", config.FileName)
+	}
+	token := os.Getenv("JIRA_TOKEN")
+	if token == "" {
+		token = cfg.Jira.Token
+	}
+	return NewJira(cfg.Jira.URL, cfg.Jira.User, token), nil
+}
+
+// hostingTracker serves #N keys from the hosting provider's issues.
+type hostingTracker struct {
+	provider hosting.Provider
+}
+
+func (t hostingTracker) Issue(ctx context.Context, key string) (*Issue, error) {
+	n, err := strconv.Atoi(strings.TrimPrefix(key, "#"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid issue key %q", key)
+	}
+	issue, err := t.provider.Issue(ctx, n)
+	if err != nil {
+		return nil, err
+	}
+	return &Issue{Key: key, Title: issue.Title, URL: issue.URL}, nil
+}
+
+// Link records the issue on branch so commits and PRs can reference it.
+func Link(ctx context.Context, dir, branch string, issue Issue) error {
+	if err := git.SetBranchConfig(ctx, dir, branch, KeyConfig, issue.Key); err != nil {
+		return err
+	}
+	if issue.URL != "" {
+		return git.SetBranchConfig(ctx, dir, branch, URLConfig, issue.URL)
+	}
+	return nil
+}
+
+// LinkedKey returns the issue key linked to branch, if any.
+func LinkedKey(ctx context.Context, dir, branch string) string {
+	return git.BranchConfig(ctx, dir, branch, KeyConfig)
+}
+
+// PrepareCommitMsg implements the prepare-commit-msg hook: it prefixes the message
+// in msgFile with the issue key linked to the current branch. Merge messages and
+// messages that already mention the key are left alone.
+func PrepareCommitMsg(ctx context.Context, dir, msgFile, source string) error {
+	if source == "merge" {
+		return nil
+	}
+	branch, err := git.CurrentBranch(ctx, dir)
+	if err != nil {
+		return nil
+	}
+	key := LinkedKey(ctx, dir, branch)
+	if key == "" {
+		return nil
+	}
+	data, err := os.ReadFile(msgFile)
+	if err != nil {
+		return err
+	}
+	msg := string(data)
+	if strings.Contains(msg, key) {
+		return nil
+	}
+	// find the first line that is not a comment; that is where the subject goes
+	lines := strings.Split(msg, "\n")
+	for i, ln := range lines {
+		if strings.HasPrefix(ln, "#") {
+			continue
+		}
+		if strings.TrimSpace(ln) == "" {
+			lines[i] = key + ": "
+		} else {
+			lines[i] = key + ": " + ln
+		}
+		break
+	}
+	return os.WriteFile(msgFile, []byte(strings.Join(lines, "\n")), 0o644)
+}
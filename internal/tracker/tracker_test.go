@@ -0,0 +1,108 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package tracker
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/config"
+)
+
+func TestParseKey(t *testing.T) {
+	var none config.Config
+	jira := config.Config{}
+	jira.Jira.URL = "https://acme.atlassian.net"
+	projects := jira
+	projects.Jira.Projects = []string{"PROJ", "WEB"}
+
+	tests := []struct {
+		name string
+		in   string
+		cfg  config.Config
+		key  string // "" when in is no key
+	}{
+		{"hosting issue", "#45", none, "#45"},
+		{"spaces around", "  #45 ", none, "#45"},
+		{"hash without number", "#abc", none, ""},
+		{"jira without jira", "PROJ-123", none, ""},
+		{"jira", "PROJ-123", jira, "PROJ-123"},
+		{"digits in project", "AB2-7", jira, "AB2-7"},
+		{"lower case", "proj-123", jira, ""},
+		{"branch name", "release-2", jira, ""},
+		{"one letter project", "A-1", jira, ""},
+		{"listed project", "WEB-9", projects, "WEB-9"},
+		{"unlisted project", "OPS-9", projects, ""},
+		{"no number", "PROJ-", jira, ""},
+		{"text", "add login form", jira, ""},
+		{"empty", "", jira, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, ok := ParseKey(tt.in, tt.cfg)
+			if ok != (tt.key != "") || ok && key != tt.key {
+				t.Errorf("ParseKey(%q) = %q, %v; want %q", tt.in, key, ok, tt.key)
+			}
+		})
+	}
+}
+
+func TestPrepareCommitMsg(t *testing.T) {
+	tests := []struct {
+		name   string
+		branch string // checked out; feature/login is linked to PROJ-7
+		source string
+		msg    string
+		want   string
+	}{
+		{name: "message", branch: "feature/login", source: "message", msg: "Add form\n", want: "PROJ-7: Add form\n"},
+		{
+			name: "editor template", branch: "feature/login",
+			msg:  "\n# Please enter the commit message\n",
+			want: "PROJ-7: \n# Please enter the commit message\n",
+		},
+		{
+			name: "comment first", branch: "feature/login", source: "template",
+			msg:  "# Summary\nAdd form\n",
+			want: "# Summary\nPROJ-7: Add form\n",
+		},
+		{name: "already mentioned", branch: "feature/login", source: "message", msg: "Fix PROJ-7 for good\n", want: "Fix PROJ-7 for good\n"},
+		{name: "merge", branch: "feature/login", source: "merge", msg: "Merge branch 'main'\n", want: "Merge branch 'main'\n"},
+		{name: "unlinked branch", branch: "main", source: "message", msg: "Add form\n", want: "Add form\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			dir := t.TempDir()
+			for _, args := range [][]string{
+				{"init", "-q", "-b", "main"},
+				{"-c", "user.name=Ama", "-c", "user.email=ama@example.com", "commit", "-q", "--allow-empty", "-m", "init"},
+				{"branch", "feature/login"},
+				{"switch", "-q", tt.branch},
+			} {
+				if out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput(); err != nil {
+					t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+				}
+			}
+			if err := Link(ctx, dir, "feature/login", Issue{Key: "PROJ-7"}); err != nil {
+				t.Fatal(err)
+			}
+			file := filepath.Join(dir, "COMMIT_EDITMSG")
+			if err := os.WriteFile(file, []byte(tt.msg), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := PrepareCommitMsg(ctx, dir, file, tt.source); err != nil {
+				t.Fatal(err)
+			}
+			if got, _ := os.ReadFile(file); string(got) != tt.want {
+				t.Errorf("message = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,284 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package tui
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ---------------- App Shell ----------------
+
+// A flow is a command as plain Go code: it checks the repository, shows
+// screens and runs git between them. runApp runs a flow in one Bubble Tea
+// program, so its screens replace each other without the terminal being
+// reset in between. The program starts with the first screen; flows that
+// print a message and return never start it.
+
+// shell is the app the running flow shows its screens in; nil outside runApp.
+var shell *appShell
+
+type appShell struct {
+	ctx     context.Context
+	cancel  context.CancelFunc // stops the flow
+	p       *tea.Program
+	exited  chan struct{} // closed when the program has ended
+	nextID  int
+	home    bool // ctrl+c leaves the app from the screens of workflows
+	started atomic.Bool
+}
+
+// runApp runs flow with its screens in one program. Inside another flow, e.g.
+// a fix started from `gitmate ready`, and headless, it just runs flow.
+func runApp(ctx context.Context, flow func(context.Context) error) error {
+	return startApp(ctx, false, flow)
+}
+
+func startApp(ctx context.Context, home bool, flow func(context.Context) error) error {
+	if shell != nil || mode.Headless {
+		return flow(ctx)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	s := &appShell{ctx: ctx, cancel: cancel, exited: make(chan struct{}), home: home}
+	shell = s
+	defer func() { shell = nil }()
+
+	err := flow(ctx)
+	if !s.started.Load() {
+		return err
+	}
+	select {
+	case <-s.exited:
+		// the program ended first, e.g. on ctrl+c
+		if err == nil {
+			err = interrupted()
+		}
+	default:
+		s.p.Send(flowDoneMsg{})
+		<-s.exited
+	}
+	return err
+}
+
+// start runs the program on the first screen. When the program ends before
+// the flow, e.g. on ctrl+c, the flow's context is cancelled.
+func (s *appShell) start() {
+	if s.started.Swap(true) {
+		return
+	}
+	s.p = tea.NewProgram(app{home: s.home}, tea.WithContext(s.ctx))
+	go func() {
+		defer close(s.exited)
+		// the flow can't show anything anymore; stop what it runs
+		defer s.cancel()
+		_, _ = s.p.Run()
+	}()
+}
+
+// open shows m and returns its id and where its final state arrives.
+func (s *appShell) open(m tea.Model, full bool) (int, chan tea.Model) {
+	s.start()
+	s.nextID++
+	done := make(chan tea.Model, 1)
+	s.p.Send(showMsg{id: s.nextID, model: m, full: full, done: done})
+	return s.nextID, done
+}
+
+// wait returns the final state of a screen, or m when the program ended first.
+func (s *appShell) wait(m tea.Model, done chan tea.Model) (tea.Model, error) {
+	select {
+	case final := <-done:
+		return final, nil
+	case <-s.exited:
+		return m, interrupted()
+	}
+}
+
+// run is runProgram inside the app: m is shown while orchestrate drives it.
+func (s *appShell) run(ctx context.Context, m tea.Model, orchestrate func(context.Context, sender)) (tea.Model, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	id, done := s.open(m, false)
+	if orchestrate == nil {
+		return s.wait(m, done)
+	}
+	es := &endSender{sender: screenSender{p: s.p, id: id}}
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		orchestrate(ctx, es)
+	}()
+	final, err := s.wait(m, done)
+	if !es.ended.Load() {
+		// left while git was still running: stop it before moving on
+		cancel()
+		<-finished
+		if err == nil {
+			err = interrupted()
+		}
+	}
+	return final, err
+}
+
+// println prints text above the app; it reports false when no app is showing.
+func (s *appShell) println(text string) bool {
+	if s == nil || !s.started.Load() {
+		return false
+	}
+	s.p.Send(tea.Println(strings.TrimRight(text, "\n"))())
+	return true
+}
+
+// screenSender tags the messages of an orchestration with its screen, so they
+// don't reach the screens shown after it.
+type screenSender struct {
+	p  *tea.Program
+	id int
+}
+
+func (s screenSender) Send(msg tea.Msg) { s.p.Send(screenMsg{id: s.id, msg: msg}) }
+
+// show displays m until it quits and returns its final state, like
+// tea.NewProgram(m).Run() but inside the app when there is one. fullScreen
+// models get the alternate screen.
+func show(m tea.Model) (tea.Model, error) {
+	full := false
+	if f, ok := m.(fullScreen); ok {
+		m, full = f.Model, true
+	}
+	if shell == nil {
+		var opts []tea.ProgramOption
+		if full {
+			opts = append(opts, tea.WithAltScreen())
+		}
+		return tea.NewProgram(m, opts...).Run()
+	}
+	_, done := shell.open(m, full)
+	return shell.wait(m, done)
+}
+
+// fullScreen asks show for the alternate screen, e.g. for long pickers.
+type fullScreen struct{ tea.Model }
+
+// ---------------- App Model ----------------
+
+type (
+	showMsg struct {
+		id    int
+		model tea.Model
+		full  bool
+		done  chan<- tea.Model
+	}
+	screenMsg struct {
+		id  int
+		msg tea.Msg
+	}
+	screenQuitMsg struct{ id int }
+	flowDoneMsg   struct{}
+)
+
+// app hosts the screen the flow shows. Commands of the screen are wrapped so
+// that its tea.Quit ends the screen instead of the program, and their
+// messages only reach the screen that asked for them.
+type app struct {
+	screen tea.Model // nil between screens
+	id     int
+	full   bool
+	done   chan<- tea.Model
+	size   *tea.WindowSizeMsg // for the screens shown later
+	home   bool
+}
+
+func (a app) Init() tea.Cmd { return nil }
+
+func (a app) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case showMsg:
+		a.screen, a.id, a.full, a.done = msg.model, msg.id, msg.full, msg.done
+		cmds := []tea.Cmd{wrap(a.id, a.screen.Init())}
+		if a.full {
+			cmds = append(cmds, tea.EnterAltScreen)
+		}
+		if a.size != nil {
+			var cmd tea.Cmd
+			a, cmd = a.forward(*a.size)
+			cmds = append(cmds, cmd)
+		}
+		return a, tea.Batch(cmds...)
+	case screenMsg:
+		if msg.id != a.id {
+			return a, nil
+		}
+		return a.forward(msg.msg)
+	case screenQuitMsg:
+		if msg.id != a.id || a.screen == nil {
+			return a, nil
+		}
+		final := a.screen
+		a.screen = nil
+		a.done <- final
+		if a.full {
+			return a, tea.ExitAltScreen
+		}
+		// leave the last frame of the screen in the scrollback, as a program would
+		if v := strings.TrimRight(final.View(), "\n"); v != "" {
+			return a, tea.Println(v)
+		}
+		return a, nil
+	case flowDoneMsg:
+		return a, tea.Quit
+	case tea.WindowSizeMsg:
+		a.size = &msg
+	case tea.KeyMsg:
+		// in the menu ctrl+c is the menu's to handle; in a workflow it leaves GitMate
+		if _, menu := a.screen.(homeModel); a.home && !menu && key.Matches(msg, interrupt) {
+			return a, tea.Quit
+		}
+	}
+	return a.forward(msg)
+}
+
+func (a app) forward(msg tea.Msg) (app, tea.Cmd) {
+	if a.screen == nil {
+		return a, nil
+	}
+	var cmd tea.Cmd
+	a.screen, cmd = a.screen.Update(msg)
+	return a, wrap(a.id, cmd)
+}
+
+func (a app) View() string {
+	if a.screen == nil {
+		return ""
+	}
+	return a.screen.View()
+}
+
+// wrap tags the messages of cmd with the screen id.
+func wrap(id int, cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		switch msg := cmd().(type) {
+		case nil:
+			return nil
+		case tea.QuitMsg:
+			return screenQuitMsg{id: id}
+		case tea.BatchMsg:
+			cmds := make(tea.BatchMsg, len(msg))
+			for i, c := range msg {
+				cmds[i] = wrap(id, c)
+			}
+			return cmds
+		default:
+			return screenMsg{id: id, msg: msg}
+		}
+	}
+}
@@ -0,0 +1,247 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package tui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/config"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/failure"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/git"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/hosting"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ChecksOptions configures `gitmate checks`.
+type ChecksOptions struct {
+	Wait     bool          // keep polling until every check has completed
+	Interval time.Duration // delay between polls
+	MaxWait  time.Duration // give up waiting after this long; 0 waits forever
+}
+
+// noChecksPolls is how many polls --wait makes before it decides that no CI
+// reports checks for the commit, instead of waiting for them forever.
+const noChecksPolls = 6
+
+// ---------------- Fetching ----------------
+
+type checksMsg struct {
+	checks []hosting.Check
+	err    error
+}
+
+// currentProvider returns the hosting provider for HEAD's upstream remote, or origin.
+func currentProvider(ctx context.Context, cfg config.Config) (hosting.Provider, error) {
+	remote := "origin"
+	if upstream, err := git.Upstream(ctx, "."); err == nil {
+		remote, _ = git.SplitRemoteRef(upstream)
+	}
+	return hosting.Detect(ctx, ".", remote, cfg)
+}
+
+// fetchChecks asks the provider for the checks on sha, after an optional delay.
+func fetchChecks(ctx context.Context, provider hosting.Provider, sha string, delay time.Duration) tea.Cmd {
+	fetch := func() tea.Msg {
+		// the provider bounds each request by the configured API timeout
+		checks, err := provider.Checks(ctx, sha)
+		return checksMsg{checks: checks, err: err}
+	}
+	if delay <= 0 {
+		return fetch
+	}
+	return tea.Tick(delay, func(time.Time) tea.Msg { return fetch() })
+}
+
+// checksSummary counts checks by state.
+func checksSummary(checks []hosting.Check) (pending, failed int) {
+	for _, c := range checks {
+		switch c.State {
+		case hosting.CheckPending:
+			pending++
+		case hosting.CheckFailure:
+			failed++
+		}
+	}
+	return pending, failed
+}
+
+// renderChecks renders one line per check with a state icon and duration.
+func renderChecks(checks []hosting.Check) string {
+	if len(checks) == 0 {
+		return "No checks reported for this commit.\n"
+	}
+	pass := theme.good()
+	fail := theme.bad()
+	dim := theme.muted()
+
+	s := ""
+	for _, c := range checks {
+		var line string
+		switch c.State {
+		case hosting.CheckSuccess:
+			line = pass.Render("✅ " + c.Name)
+		case hosting.CheckFailure:
+			line = fail.Render("❌ " + c.Name)
+		case hosting.CheckSkipped:
+			line = dim.Render("⏭  " + c.Name)
+		default:
+			line = "⏳ " + c.Name
+		}
+		if d := c.Duration(); d > 0 {
+			line += dim.Render(" " + d.Round(time.Second).String())
+		}
+		s += line + "\n"
+	}
+	return s
+}
+
+// ---------------- Checks Model ----------------
+
+type checksModel struct {
+	ctx      context.Context
+	spinner  spinner.Model
+	provider hosting.Provider
+	sha      string
+	opts     ChecksOptions
+	checks   []hosting.Check
+	loaded   bool
+	polls    int
+	deadline time.Time // zero without --max-wait
+	timedOut bool      // --max-wait passed with checks still running
+	help     helpOverlay
+	err      error
+	done     bool
+}
+
+func newChecksModel(ctx context.Context, provider hosting.Provider, sha string, opts ChecksOptions) checksModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	m := checksModel{ctx: ctx, spinner: s, provider: provider, sha: sha, opts: opts}
+	if opts.Wait && opts.MaxWait > 0 {
+		m.deadline = time.Now().Add(opts.MaxWait)
+	}
+	return m
+}
+
+func (m checksModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, fetchChecks(m.ctx, m.provider, m.sha, 0))
+}
+
+func (m checksModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if key.Matches(msg, interrupt) || key.Matches(msg, keys.Quit) && !m.help.open {
+			return m, tea.Quit
+		}
+		m.help, _ = m.help.update(msg)
+	case spinner.TickMsg:
+		if !m.done {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	case checksMsg:
+		m.loaded = true
+		m.polls++
+		m.checks, m.err = msg.checks, msg.err
+		pending, _ := checksSummary(m.checks)
+		delay := m.opts.Interval
+		if !m.deadline.IsZero() {
+			delay = min(delay, time.Until(m.deadline))
+		}
+		switch {
+		case m.err != nil, !m.opts.Wait, len(m.checks) > 0 && pending == 0:
+		case len(m.checks) == 0 && m.polls >= noChecksPolls:
+			// nothing reports checks for this commit; there is nothing to wait for
+		case delay <= 0:
+			m.timedOut = true
+		default:
+			return m, fetchChecks(m.ctx, m.provider, m.sha, delay)
+		}
+		m.done = true
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m checksModel) View() string {
+	s := fmt.Sprintf("GitMate: Checks for %s\n\n", shortHash(m.sha))
+	if m.help.open {
+		return s + m.help.View([]key.Binding{keys.Quit, interrupt, keys.Help})
+	}
+	if m.err != nil {
+		return s + "Error: " + m.err.Error() + "\n"
+	}
+	if m.loaded {
+		s += renderChecks(m.checks) + "\n"
+	}
+	pending, failed := checksSummary(m.checks)
+	switch {
+	case !m.done && len(m.checks) == 0:
+		s += m.spinner.View() + " Waiting for checks to be reported...\n"
+		s += "\n" + shortHelp(keys.Quit, keys.Help) + "\n"
+	case !m.done:
+		s += m.spinner.View() + fmt.Sprintf(" Waiting for %d checks...\n", pending)
+		s += "\n" + shortHelp(keys.Quit, keys.Help) + "\n"
+	case failed > 0:
+		s += fmt.Sprintf("%d checks failed.\n", failed)
+	case m.timedOut:
+		s += fmt.Sprintf("%d checks still running after %s.\n", pending, m.opts.MaxWait)
+	case pending > 0:
+		s += fmt.Sprintf("%d checks still running. Use --wait to follow them.\n", pending)
+	case len(m.checks) > 0:
+		s += "All checks passed.\n"
+	}
+	return s
+}
+
+// ---------------- Public Entry ----------------
+
+// RunChecksTUI shows the CI checks for HEAD and returns an error if any failed.
+func RunChecksTUI(ctx context.Context, opts ChecksOptions) error {
+	cfg, err := config.Load(ctx, ".")
+	if err != nil {
+		return err
+	}
+	provider, err := currentProvider(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	sha, err := git.RevParse(ctx, ".", "HEAD")
+	if err != nil {
+		return err
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = 10 * time.Second
+	}
+
+	final, err := runProgram(ctx, newChecksModel(ctx, provider, sha, opts), nil)
+	if err != nil {
+		return err
+	}
+	m, ok := final.(checksModel)
+	if !ok {
+		return nil
+	}
+	if jsonOutput() {
+		output.data(checkResults(m.checks))
+	} else if mode.Headless {
+		fmt.Print(m.View())
+	}
+	if m.err != nil {
+		return finished(remoteFailure(m.err))
+	}
+	if _, failed := checksSummary(m.checks); failed > 0 {
+		return finished(failure.Errorf(failure.Checks, "%d checks failed", failed))
+	}
+	if m.timedOut {
+		pending, _ := checksSummary(m.checks)
+		return finished(failure.Errorf(failure.Timeout, "%d checks still running after %s (raise --max-wait)", pending, opts.MaxWait))
+	}
+	return nil
+}
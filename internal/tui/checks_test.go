@@ -0,0 +1,208 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/failure"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/hosting"
+)
+
+// check is a check named after its state.
+func check(state hosting.CheckState) hosting.Check {
+	return hosting.Check{Name: string(state), State: state}
+}
+
+func TestChecksModelPolling(t *testing.T) {
+	pending := []hosting.Check{check(hosting.CheckSuccess), check(hosting.CheckPending)}
+	tests := []struct {
+		name     string
+		opts     ChecksOptions
+		polls    []checksMsg // the answers of the provider, in order
+		done     bool
+		timedOut bool
+	}{
+		{"without --wait", ChecksOptions{}, []checksMsg{{checks: pending}}, true, false},
+		{"waits for pending checks", ChecksOptions{Wait: true}, []checksMsg{{checks: pending}}, false, false},
+		{
+			"all completed", ChecksOptions{Wait: true},
+			[]checksMsg{{checks: pending}, {checks: []hosting.Check{check(hosting.CheckSuccess), check(hosting.CheckFailure)}}},
+			true, false,
+		},
+		{"error", ChecksOptions{Wait: true}, []checksMsg{{err: errors.New("github: 401 Bad credentials")}}, true, false},
+		{"max wait", ChecksOptions{Wait: true, MaxWait: time.Nanosecond}, []checksMsg{{checks: pending}}, true, true},
+		{"no checks yet", ChecksOptions{Wait: true}, make([]checksMsg, noChecksPolls-1), false, false},
+		{"no checks at all", ChecksOptions{Wait: true}, make([]checksMsg, noChecksPolls), true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.Interval = time.Second
+			m := newChecksModel(context.Background(), nil, "abc123", tt.opts)
+			time.Sleep(time.Millisecond) // let a --max-wait of 1ns pass
+			for _, msg := range tt.polls {
+				if m.done {
+					t.Fatal("polled again after it was done")
+				}
+				next, cmd := m.Update(msg)
+				m = next.(checksModel)
+				if cmd == nil {
+					t.Fatal("Update returned no command: it neither polls again nor quits")
+				}
+			}
+			if m.done != tt.done || m.timedOut != tt.timedOut {
+				t.Errorf("done, timedOut = %v, %v; want %v, %v", m.done, m.timedOut, tt.done, tt.timedOut)
+			}
+		})
+	}
+}
+
+// checksRepo makes a repository on github.com/acme/app whose API is served by
+// answer, called with the number of the poll, and changes into it. It returns
+// how many polls were made.
+func checksRepo(t *testing.T, answer func(poll int) (status int, runs string)) func() int {
+	t.Helper()
+	var polls atomic.Int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/status") {
+			fmt.Fprint(w, `{"statuses": []}`)
+			return
+		}
+		status, runs := answer(int(polls.Add(1)))
+		w.WriteHeader(status)
+		fmt.Fprint(w, runs)
+	}))
+	t.Cleanup(api.Close)
+
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"remote", "add", "origin", "https://github.com/acme/app.git"},
+		{"-c", "user.name=Ama", "-c", "user.email=ama@example.com", "commit", "-q", "--allow-empty", "-m", "init"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+		}
+	}
+	cfg := fmt.Sprintf("hosting: github\ngithub:\n  apiURL: %s\n", api.URL)
+	if err := os.WriteFile(filepath.Join(dir, ".gitmate.yml"), []byte(cfg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GITHUB_TOKEN", "tok")
+	t.Chdir(dir)
+
+	SetRunMode(RunMode{Headless: true})
+	StartOutput(OutputText, "checks")
+	return func() int { return int(polls.Load()) }
+}
+
+// runs renders check runs, all in the given status and conclusion.
+func runs(status, conclusion string, names ...string) string {
+	var rs []string
+	for _, n := range names {
+		rs = append(rs, fmt.Sprintf(`{"name": %q, "status": %q, "conclusion": %q}`, n, status, conclusion))
+	}
+	return `{"check_runs": [` + strings.Join(rs, ",") + `]}`
+}
+
+func TestChecksWaitExitCodes(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    ChecksOptions
+		answer  func(poll int) (int, string)
+		kind    failure.Kind // 0 for success
+		minPoll int          // polls --wait must have made
+	}{
+		{
+			name: "passed",
+			opts: ChecksOptions{Wait: true},
+			answer: func(int) (int, string) {
+				return http.StatusOK, runs("completed", "success", "build", "test")
+			},
+		},
+		{
+			name: "waits for pending checks",
+			opts: ChecksOptions{Wait: true},
+			answer: func(poll int) (int, string) {
+				if poll < 3 {
+					return http.StatusOK, runs("in_progress", "", "build")
+				}
+				return http.StatusOK, runs("completed", "success", "build")
+			},
+			minPoll: 3,
+		},
+		{
+			name: "failed",
+			opts: ChecksOptions{Wait: true},
+			answer: func(int) (int, string) {
+				return http.StatusOK, runs("completed", "failure", "build")
+			},
+			kind: failure.Checks,
+		},
+		{
+			name: "skipped counts as passed",
+			opts: ChecksOptions{Wait: true},
+			answer: func(int) (int, string) {
+				return http.StatusOK, runs("completed", "skipped", "docs")
+			},
+		},
+		{
+			name: "max wait",
+			opts: ChecksOptions{Wait: true, MaxWait: 50 * time.Millisecond},
+			answer: func(int) (int, string) {
+				return http.StatusOK, runs("in_progress", "", "build")
+			},
+			kind: failure.Timeout,
+		},
+		{
+			name: "no checks",
+			opts: ChecksOptions{Wait: true},
+			answer: func(int) (int, string) {
+				return http.StatusOK, runs("", "")
+			},
+			minPoll: noChecksPolls,
+		},
+		{
+			name: "pending without wait",
+			opts: ChecksOptions{},
+			answer: func(int) (int, string) {
+				return http.StatusOK, runs("queued", "", "build")
+			},
+		},
+		{
+			name: "bad token",
+			opts: ChecksOptions{Wait: true},
+			answer: func(int) (int, string) {
+				return http.StatusUnauthorized, `{"message": "Bad credentials"}`
+			},
+			kind: failure.Remote,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			polls := checksRepo(t, tt.answer)
+			opts := tt.opts
+			opts.Interval = time.Millisecond
+
+			err := RunChecksTUI(context.Background(), opts)
+			if got := failure.KindOf(err); got != tt.kind {
+				t.Errorf("RunChecksTUI = %v (exit %d), want exit %d", err, failure.ExitCode(err), tt.kind)
+			}
+			if n := polls(); n < tt.minPoll {
+				t.Errorf("polled %d times, want at least %d", n, tt.minPoll)
+			}
+		})
+	}
+}
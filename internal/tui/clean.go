@@ -5,197 +5,157 @@ package tui
 
 import (
 	"context"
-	"fmt"
 	"regexp"
 	"strings"
 
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/config"
 	"github.com/Orctatech-Engineering-Team/GitMate/internal/git"
-	"github.com/charmbracelet/bubbles/list"
-	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/workflow"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
-// ---------------- Prompt Model ----------------
-
-type confirmModel struct {
-	list list.Model
-	done bool
-	yes  bool
-}
-
-func NewConfirmModel() confirmModel {
-	items := []list.Item{
-		listItem2("Yes, run autosquash rebase"),
-		listItem2("No, cancel"),
-	}
-	l := list.New(items, list.NewDefaultDelegate(), 100, 10)
-	l.Title = "Noisy commits detected. Run 'git rebase -i --autosquash'?"
-	return confirmModel{list: l}
-}
-
-type listItem2 string
-
-func (i listItem2) FilterValue() string { return string(i) }
-
-func (m confirmModel) Init() tea.Cmd { return nil }
-
-func (m confirmModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		switch msg.String() {
-		case "enter":
-			//i, ok := m.list.SelectedItem().(listItem)
-			//if ok {
-			//	if i == listItem2("Yes, run autosquash rebase") {
-			//		m.yes = true
-			//	}
-			//	m.done = true
-			//	return m, tea.Quit
-			//}
-		case "q", "ctrl+c":
-			m.done = true
-			m.yes = false
-			return m, tea.Quit
-		}
-	}
-	var cmd tea.Cmd
-	m.list, cmd = m.list.Update(msg)
-	return m, cmd
-}
-
-func (m confirmModel) View() string {
-	if m.done {
-		return ""
-	}
-	return m.list.View()
-}
-
 // ---------------- Clean Model ----------------
 
 type cleanModel struct {
-	spinner spinner.Model
-	logs    []string
-	err     error
-	done    bool
-	noisy   []string
-}
-
-func NewCleanModel(noisyCommits []string) cleanModel {
-	s := spinner.New()
-	s.Spinner = spinner.Dot
-	return cleanModel{
-		spinner: s,
-		noisy:   noisyCommits,
-		logs:    []string{},
-	}
+	runModel
+	noisy []string
 }
 
-func (m cleanModel) Init() tea.Cmd {
-	return m.spinner.Tick
+func NewCleanModel(ctx context.Context, base string, noisyCommits []string) cleanModel {
+	m := cleanModel{runModel: newRunModel(ctx, "Cleaning noisy commits", cleanWorkflow(base), nil), noisy: noisyCommits}
+	m.quitOnDone = true
+	return m
 }
 
 func (m cleanModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		if msg.String() == "q" || msg.String() == "ctrl+c" {
-			return m, tea.Quit
-		}
-	case spinner.TickMsg:
-		if !m.done {
-			var cmd tea.Cmd
-			m.spinner, cmd = m.spinner.Update(msg)
-			return m, cmd
-		}
-	case gitLineMsg:
-		m.logs = append(m.logs, string(msg))
-	case gitErrMsg:
-		m.err = msg
-		m.done = true
-		return m, tea.Quit
-	case gitDoneMsg:
-		m.done = true
-		return m, tea.Quit
-	}
-	return m, nil
+	var cmd tea.Cmd
+	m.runModel, cmd = m.update(msg)
+	return m, cmd
 }
 
 func (m cleanModel) View() string {
-	s := "GitMate: Cleaning noisy commits\n\n"
-	if m.err != nil {
-		s += "Error: " + m.err.Error() + "\n"
-	} else if m.done {
-		s += "✅ Clean operation complete.\n\n"
-	} else {
-		s += m.spinner.View() + " Preparing...\n\n"
+	if m.help.open {
+		return m.helpView()
 	}
+	s := m.header.View() + "\n"
+	s += m.steps.View(m.spinner.View()) + "\n"
+	s += m.status("Preparing...", "Clean operation complete.") + "\n"
 	if len(m.noisy) > 0 {
 		s += "Noisy commits:\n"
 		for _, c := range m.noisy {
-			s += fmt.Sprintf(" - %s\n", c)
+			s += theme.muted().Render(" - "+c) + "\n"
 		}
+		s += "\n"
 	}
-	// show last 10 logs
-	start := 0
-	if len(m.logs) > 10 {
-		start = len(m.logs) - 10
-	}
-	for _, line := range m.logs[start:] {
-		s += line + "\n"
-	}
-	if m.done {
-		s += "\n(press q to quit)"
-	}
-	return s
+	s += m.log.View()
+	return s + m.footer()
 }
 
-// ---------------- Public Entry ----------------
+// ---------------- Detection ----------------
 
-func RunCleanTUI() error {
-	// 1. Get recent commits
-	out, err := git.RunCombined(context.Background(), ".", "log", "--oneline", "-n", "20")
-	if err != nil {
-		return err
-	}
-	lines := strings.Split(out, "\n")
+var noisyCommitRe = regexp.MustCompile(`\bfix(e[sd])?\b|\btypo\b|\bdebug\b|\boops\b`)
+
+// findNoisyCommits returns the lines of `git log --oneline` output whose message looks
+// like a fixup (typo, oops, debug, ...) that should be squashed before review.
+func findNoisyCommits(oneline string) []string {
 	noisy := []string{}
-	re := regexp.MustCompile(`\bfix(e[sd])?\b|\btypo\b|\bdebug\b|\boops\b`)
-	for _, l := range lines {
+	for _, l := range strings.Split(oneline, "\n") {
 		parts := strings.SplitN(l, " ", 2)
 		if len(parts) < 2 {
 			continue
 		}
 		msg := parts[1]
-		if re.MatchString(strings.ToLower(msg)) {
+		if noisyCommitRe.MatchString(strings.ToLower(msg)) {
 			noisy = append(noisy, l)
 		}
 	}
+	return noisy
+}
+
+// ---------------- Public Entry ----------------
+
+func RunCleanTUI(ctx context.Context) error {
+	return runApp(ctx, cleanFlow)
+}
+
+// cleanFlow finds the noisy commits and squashes them once confirmed.
+func cleanFlow(ctx context.Context) error {
+	// 1. Get the commits of the branch; trunk history is published and stays as is
+	cfg, err := config.Load(ctx, ".")
+	if err != nil {
+		return err
+	}
+	base, err := git.MergeBase(ctx, ".", git.TrunkRef(ctx, ".", cfg.Trunk), "HEAD")
+	if err != nil {
+		return err
+	}
+	out, err := git.RunCombined(ctx, ".", "log", "--oneline", base+"..HEAD")
+	if err != nil {
+		return err
+	}
+	noisy := findNoisyCommits(out)
+	output.noisy(noisy)
 
 	if len(noisy) == 0 {
-		fmt.Println("No noisy commits detected. Nothing to clean.")
+		say("No noisy commits detected. Nothing to clean.\n")
 		return nil
 	}
 
 	// 2. Prompt user for confirmation
-	pm := tea.NewProgram(NewConfirmModel())
-	final, err := pm.Run()
-	if err != nil {
-		return err
-	}
-	if p, ok := final.(confirmModel); ok && p.yes {
-		// 3. Run interactive autosquash rebase with live logs
-		cm := tea.NewProgram(NewCleanModel(noisy))
-		go runClean(cm)
-		_, err := cm.Run()
+	confirmed, err := confirmClean(noisy)
+	if err != nil || !confirmed {
 		return err
 	}
 
+	// 3. Run interactive autosquash rebase with live logs
+	snap := snapshot(ctx)
+	final, err := runProgram(ctx, NewCleanModel(ctx, base, noisy), func(ctx context.Context, p sender) {
+		runWorkflow(ctx, p, cleanWorkflow(base), nil)
+	})
+	if err != nil {
+		return rollbackAfter(ctx, snap, final, err)
+	}
+	m, ok := final.(cleanModel)
+	if !ok || !m.done {
+		return nil
+	}
+	if m.err != nil {
+		return rollbackAfter(ctx, snap, m, finished(m.err))
+	}
+	printCoachSummary(ctx, ".")
 	return nil
 }
 
+// confirmClean asks whether to rebase, unless --yes already answered.
+func confirmClean(noisy []string) (bool, error) {
+	if mode.Yes {
+		return true, nil
+	}
+	if mode.Headless {
+		return false, needsAnswer("cleaning up commits", "--yes")
+	}
+	body := "Noisy commits detected:\n"
+	for _, c := range noisy {
+		body += " - " + c + "\n"
+	}
+	return confirm(body+"\n", "Run 'git rebase -i --autosquash'?")
+}
+
 // ---------------- Orchestration ----------------
 
-func runClean(p *tea.Program) {
-	streamStep(p, "rebase", []string{"-i", "--autosquash", "HEAD~20"}, func() {
-		p.Send(gitDoneMsg{})
-	})
+// cleanWorkflow squashes the fixup commits of the branch, i.e. those after base.
+func cleanWorkflow(base string) workflow.Workflow {
+	args := []string{"rebase", "-i", "--autosquash", base}
+	if mode.Headless {
+		// there is no one to edit the todo list; take the autosquashed order as is
+		args = append([]string{"-c", "sequence.editor=:"}, args...)
+	}
+	return workflow.Workflow{Name: "clean", Steps: []workflow.Step{
+		leaseStep,
+		{
+			Name:    "Squash fixup commits",
+			Explain: "Fold fixup! and squash! commits into the commits they fix.",
+			Git:     args,
+		},
+	}}
 }
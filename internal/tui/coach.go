@@ -0,0 +1,350 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/config"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/git"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// CoachOptions configures `gitmate coach`.
+type CoachOptions struct {
+	Days int // how far back to look; config coach.days when zero
+}
+
+// ---------------- Activity ----------------
+
+type reflogEntry struct {
+	hash    string
+	subject string
+	when    time.Time
+}
+
+// readReflog returns ref's reflog entries newer than since, newest first.
+func readReflog(ctx context.Context, dir, ref string, since time.Time) ([]reflogEntry, error) {
+	out, err := git.RunCombined(ctx, dir, "reflog", "show", "--format=%H%x00%gs%x00%ct", ref, "--")
+	if err != nil {
+		return nil, err
+	}
+	var entries []reflogEntry
+	for _, line := range strings.Split(out, "\n") {
+		parts := strings.SplitN(line, "\x00", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		unix, _ := strconv.ParseInt(parts[2], 10, 64)
+		when := time.Unix(unix, 0)
+		if when.Before(since) {
+			break
+		}
+		entries = append(entries, reflogEntry{hash: parts[0], subject: parts[1], when: when})
+	}
+	return entries, nil
+}
+
+// ---------------- Tips ----------------
+
+// coachTip is one piece of advice with the evidence behind it.
+type coachTip struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Evidence string `json:"evidence"`
+	Advice   string `json:"advice"`
+	Lesson   string `json:"lesson,omitempty"` // tutor lesson that practises the fix
+	count    int
+}
+
+// coachActivity runs every detector over the activity since the given time and
+// returns the tips ordered by how often the pattern occurred.
+func coachActivity(ctx context.Context, dir string, cfg config.Config, since time.Time) ([]coachTip, error) {
+	head, err := readReflog(ctx, dir, "HEAD", since)
+	if err != nil {
+		return nil, err
+	}
+	me, _ := git.RunCombined(ctx, dir, "config", "user.email")
+
+	var tips []coachTip
+	add := func(t *coachTip) {
+		if t != nil {
+			tips = append(tips, *t)
+		}
+	}
+	add(forcePushTip(ctx, dir, since))
+	add(pullMergeTip(ctx, dir, me, since))
+	add(resetTip(head))
+	add(rebaseAbortTip(head))
+	add(trunkCommitTip(ctx, dir, cfg.Trunk, head))
+	add(noisyCommitTip(ctx, dir, me, since))
+	add(staleStashTip(ctx, dir))
+
+	sort.SliceStable(tips, func(i, j int) bool { return tips[i].count > tips[j].count })
+	return tips, nil
+}
+
+// forcePushTip looks for pushes that moved a remote-tracking branch to a commit
+// that doesn't contain its previous position.
+func forcePushTip(ctx context.Context, dir string, since time.Time) *coachTip {
+	refs, err := git.RunCombined(ctx, dir, "for-each-ref", "--format=%(refname)", "refs/remotes")
+	if err != nil || refs == "" {
+		return nil
+	}
+	forced := 0
+	for _, ref := range strings.Split(refs, "\n") {
+		entries, err := readReflog(ctx, dir, ref, since)
+		if err != nil {
+			continue
+		}
+		for i := 0; i+1 < len(entries); i++ {
+			if !strings.HasPrefix(entries[i].subject, "update by push") {
+				continue
+			}
+			if ok, err := git.IsAncestor(ctx, dir, entries[i+1].hash, entries[i].hash); err == nil && !ok {
+				forced++
+			}
+		}
+	}
+	if forced == 0 {
+		return nil
+	}
+	return &coachTip{
+		ID:       "force-push",
+		Title:    "Force pushes",
+		Evidence: fmt.Sprintf("You force-pushed %s.", countNoun(forced, "time")),
+		Advice:   "A plain --force overwrites whatever is on the remote, including a teammate's commits. `gitmate push` uses --force-with-lease, which refuses if the remote moved since you last looked.",
+		Lesson:   "concepts-quiz",
+		count:    forced,
+	}
+}
+
+// pullMergeTip counts merge commits `git pull` created on your behalf.
+func pullMergeTip(ctx context.Context, dir, me string, since time.Time) *coachTip {
+	if me == "" {
+		return nil
+	}
+	out, err := git.RunCombined(ctx, dir, "log", "--all", "--merges", "--author="+me,
+		"--since="+since.Format(time.RFC3339), "--format=%s")
+	if err != nil || out == "" {
+		return nil
+	}
+	n := 0
+	for _, subject := range strings.Split(out, "\n") {
+		// pull merges name the remote: "Merge branch 'main' of github.com:org/repo"
+		if strings.HasPrefix(subject, "Merge branch '") && strings.Contains(subject, "' of ") {
+			n++
+		}
+	}
+	if n < 2 {
+		return nil
+	}
+	return &coachTip{
+		ID:       "pull-merges",
+		Title:    "Merge commits from git pull",
+		Evidence: fmt.Sprintf("`git pull` created %s.", countNoun(n, "merge commit")),
+		Advice:   "They add noise to history without adding information. `gitmate sync` rebases instead; `git config --global pull.rebase true` makes pull do the same.",
+		Lesson:   "sync",
+		count:    n,
+	}
+}
+
+func resetTip(head []reflogEntry) *coachTip {
+	n := 0
+	for _, e := range head {
+		if strings.HasPrefix(e.subject, "reset: moving to") {
+			n++
+		}
+	}
+	if n < 3 {
+		return nil
+	}
+	return &coachTip{
+		ID:       "resets",
+		Title:    "Frequent resets",
+		Evidence: fmt.Sprintf("Your reflog shows %s.", countNoun(n, "reset")),
+		Advice:   "`git reset --hard` throws away uncommitted work for good. Stash or commit first; commits you reset away can still be found with `git reflog`.",
+		Lesson:   "recover-branch",
+		count:    n,
+	}
+}
+
+func rebaseAbortTip(head []reflogEntry) *coachTip {
+	n := 0
+	for _, e := range head {
+		if strings.HasPrefix(e.subject, "rebase (abort)") {
+			n++
+		}
+	}
+	if n < 2 {
+		return nil
+	}
+	return &coachTip{
+		ID:       "rebase-aborts",
+		Title:    "Abandoned rebases",
+		Evidence: fmt.Sprintf("You aborted %s.", countNoun(n, "rebase")),
+		Advice:   "Conflicts are less scary with practice: fix the file, `git add` it and `git rebase --continue`. Syncing often keeps conflicts small.",
+		Lesson:   "conflict",
+		count:    n,
+	}
+}
+
+// trunkCommitTip replays branch switches in the HEAD reflog to find commits
+// made while trunk was checked out.
+func trunkCommitTip(ctx context.Context, dir, trunk string, head []reflogEntry) *coachTip {
+	cur, _ := git.CurrentBranch(ctx, dir)
+	n := 0
+	// newest first: entries older than a checkout happened on the branch it left
+	for _, e := range head {
+		if strings.HasPrefix(e.subject, "commit") && !strings.HasPrefix(e.subject, "commit (merge)") && cur == trunk {
+			n++
+		}
+		if from, ok := strings.CutPrefix(e.subject, "checkout: moving from "); ok {
+			cur, _, _ = strings.Cut(from, " to ")
+		}
+	}
+	if n == 0 {
+		return nil
+	}
+	return &coachTip{
+		ID:       "trunk-commits",
+		Title:    "Commits straight to " + trunk,
+		Evidence: fmt.Sprintf("You made %s while on %s.", countNoun(n, "commit"), trunk),
+		Advice:   "Work on a branch (`gitmate start <name>`) so changes are reviewed before they reach " + trunk + " and " + trunk + " stays releasable.",
+		Lesson:   "feature-branch",
+		count:    n,
+	}
+}
+
+func noisyCommitTip(ctx context.Context, dir, me string, since time.Time) *coachTip {
+	if me == "" {
+		return nil
+	}
+	out, err := git.RunCombined(ctx, dir, "log", "--all", "--no-merges", "--author="+me,
+		"--since="+since.Format(time.RFC3339), "--format=%s")
+	if err != nil || out == "" {
+		return nil
+	}
+	n := 0
+	for _, subject := range strings.Split(out, "\n") {
+		if noisyCommitRe.MatchString(strings.ToLower(subject)) || strings.HasPrefix(strings.ToLower(subject), "wip") {
+			n++
+		}
+	}
+	if n < 3 {
+		return nil
+	}
+	return &coachTip{
+		ID:       "noisy-commits",
+		Title:    "Work-in-progress commits",
+		Evidence: fmt.Sprintf("%d of your recent commits look like wip or fixups.", n),
+		Advice:   "Squash them before review so each commit tells one story. `gitmate clean` folds fixups into the commits they fix.",
+		Lesson:   "squash",
+		count:    n,
+	}
+}
+
+func staleStashTip(ctx context.Context, dir string) *coachTip {
+	out, err := git.RunCombined(ctx, dir, "stash", "list", "--format=%ct")
+	if err != nil || out == "" {
+		return nil
+	}
+	n := 0
+	for _, ts := range strings.Split(out, "\n") {
+		unix, _ := strconv.ParseInt(ts, 10, 64)
+		if time.Since(time.Unix(unix, 0)) > 14*24*time.Hour {
+			n++
+		}
+	}
+	if n == 0 {
+		return nil
+	}
+	return &coachTip{
+		ID:       "stale-stashes",
+		Title:    "Forgotten stashes",
+		Evidence: fmt.Sprintf("You have %s older than two weeks.", countNoun(n, "stashed change")),
+		Advice:   "Stashes are easy to forget. `git stash list` shows them; apply what you need and `git stash drop` the rest, or commit work to a branch instead.",
+		count:    n,
+	}
+}
+
+// ---------------- Output ----------------
+
+func renderCoachTips(tips []coachTip, days int) string {
+	head := lipgloss.NewStyle().Bold(true)
+	dim := theme.muted()
+	text := lipgloss.NewStyle().Width(76).PaddingLeft(3)
+	trim := func(block string) string {
+		lines := strings.Split(block, "\n")
+		for i, l := range lines {
+			lines[i] = strings.TrimRight(l, " ")
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	s := fmt.Sprintf("GitMate: Coach (last %d days)\n\n", days)
+	if len(tips) == 0 {
+		return s + "Nothing to flag. Nice work.\n"
+	}
+	for i, t := range tips {
+		s += head.Render(fmt.Sprintf("%d. %s", i+1, t.Title)) + "\n"
+		s += trim(text.Render(t.Evidence+" "+t.Advice)) + "\n"
+		if t.Lesson != "" {
+			s += dim.Render("   Practise: gitmate tutor "+t.Lesson) + "\n"
+		}
+		s += "\n"
+	}
+	return s
+}
+
+// printCoachSummary prints the most frequent tip in one line when the user opted
+// in with coach.summary. It never fails the command it follows.
+func printCoachSummary(ctx context.Context, dir string) {
+	cfg, err := config.Load(ctx, dir)
+	if err != nil || !cfg.Coach.Summary {
+		return
+	}
+	tips, err := coachActivity(ctx, dir, cfg, time.Now().AddDate(0, 0, -cfg.Coach.Days))
+	if err != nil || len(tips) == 0 {
+		return
+	}
+	more := ""
+	if len(tips) > 1 {
+		more = fmt.Sprintf(" (+%d more)", len(tips)-1)
+	}
+	say("💡 Coach: %s — %s%s Run `gitmate coach` for details.\n", tips[0].Title, tips[0].Evidence, more)
+}
+
+// ---------------- Public Entry ----------------
+
+// RunCoach analyses local git activity and prints personalised tips. Nothing
+// leaves the machine: it only reads the reflog, history and stash.
+func RunCoach(ctx context.Context, opts CoachOptions) error {
+	cfg, err := config.Load(ctx, ".")
+	if err != nil {
+		return err
+	}
+	days := opts.Days
+	if days <= 0 {
+		days = cfg.Coach.Days
+	}
+	tips, err := coachActivity(ctx, ".", cfg, time.Now().AddDate(0, 0, -days))
+	if err != nil {
+		return err
+	}
+	if jsonOutput() {
+		if tips == nil {
+			tips = []coachTip{}
+		}
+		output.data(tips)
+		return nil
+	}
+	fmt.Print(renderCoachTips(tips, days))
+	return nil
+}
@@ -2,42 +2,106 @@ package tui
 
 import (
 	"context"
+	"fmt"
+	"time"
+
 	"github.com/Orctatech-Engineering-Team/GitMate/internal/git"
-	tea "github.com/charmbracelet/bubbletea"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/sessionlog"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/workflow"
 )
 
 // --- messages
+type stepMsg struct{ command []string } // a command is about to run, e.g. ["git", "fetch"]
+type stepDoneMsg struct {
+	command  []string
+	duration time.Duration
+	exitCode int
+}
 type gitLineMsg string
 type gitErrMsg error
 type gitDoneMsg struct{}
+type workflowMsg workflow.Event // progress of the workflow run by runWorkflow
 
 type confirmMsg bool
 type tutorMsg string
 type tutorErrMsg error
 type tutorDoneMsg struct{}
 
-// streamStep runs a git command, streams logs/errors into Update, then calls next if success
-func streamStep(p *tea.Program, cmd string, args []string, next func()) {
-	ctx := context.Background()
-	outCh, errCh := git.RunGitWithOutput(ctx, append([]string{cmd}, args...)...)
-
-	// forward stdout
-	go func() {
-		for line := range outCh {
+// runWorkflow runs w in the current repository and reports its progress to p:
+// a workflowMsg per event, the step and line messages the models and headless
+// output consume, and finally gitDoneMsg or gitErrMsg.
+func runWorkflow(ctx context.Context, p sender, w workflow.Workflow, vars map[string]string) {
+	r := &workflow.Runner{Dir: ".", Vars: vars, Emit: func(e workflow.Event) {
+		logEvent(e)
+		switch e.Kind {
+		case workflow.CommandStarted:
+			p.Send(stepMsg{command: e.Command})
+		case workflow.CommandOutput:
+			line := e.Line
+			if e.Stderr {
+				line = "[stderr] " + line
+			}
 			p.Send(gitLineMsg(line))
+		case workflow.CommandDone:
+			p.Send(stepDoneMsg{command: e.Command, duration: e.Duration, exitCode: e.ExitCode})
+		case workflow.StepRetrying:
+			p.Send(gitLineMsg(fmt.Sprintf("[warn] %s failed, trying again (attempt %d): %v", e.Name, e.Attempt, e.Err)))
 		}
-	}()
-
-	// forward stderr/errors
-	go func() {
-		for err := range errCh {
-			if err != nil {
-				p.Send(gitErrMsg(err))
-				return
-			}
+		p.Send(workflowMsg(e))
+	}}
+	if err := r.Run(ctx, w); err != nil {
+		p.Send(gitErrMsg(err))
+		return
+	}
+	p.Send(gitDoneMsg{})
+}
+
+// logEvent writes the commands of a workflow and their output to the session log.
+func logEvent(e workflow.Event) {
+	switch e.Kind {
+	case workflow.CommandStarted:
+		sessionlog.Command(e.Command)
+	case workflow.CommandOutput:
+		// of git's --progress updates only the last of each phase is worth a line
+		if p, ok := git.ParseProgress(e.Line); ok && !p.Done {
+			return
 		}
-		if next != nil {
-			next()
+		if e.Stderr {
+			sessionlog.Printf("[stderr] %s", e.Line)
+		} else {
+			sessionlog.Printf("%s", e.Line)
 		}
-	}()
+	case workflow.CommandDone:
+		sessionlog.CommandDone(e.Command, e.Duration, e.ExitCode)
+	case workflow.StepRetrying:
+		sessionlog.Printf("%s failed, trying again (attempt %d): %v", e.Name, e.Attempt, e.Err)
+	case workflow.StepFailed:
+		sessionlog.Printf("step failed: %v", e.Err)
+	}
+}
+
+// humanizeAge renders the time since t as a short relative age ("3 days ago").
+func humanizeAge(t time.Time) string {
+	if t.IsZero() {
+		return "unknown"
+	}
+	d := time.Since(t)
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%d minutes ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%d hours ago", int(d.Hours()))
+	case d < 60*24*time.Hour:
+		return fmt.Sprintf("%d days ago", int(d.Hours()/24))
+	default:
+		return fmt.Sprintf("%d months ago", int(d.Hours()/24/30))
+	}
+}
+
+// shortHash abbreviates a commit hash for display.
+func shortHash(h string) string {
+	if len(h) > 7 {
+		return h[:7]
+	}
+	return h
 }
@@ -0,0 +1,218 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package tui
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/failure"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/git"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/workflow"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ---------------- Header ----------------
+
+// header is the title of a screen with the repository and branch it works on.
+type header struct {
+	title  string
+	repo   string // name of the repository's top-level directory
+	branch string
+}
+
+// newHeader reads the repository and branch once; View runs on every frame.
+func newHeader(ctx context.Context, title string) header {
+	h := header{title: title}
+	if top, err := git.RunCombined(ctx, ".", "rev-parse", "--show-toplevel"); err == nil {
+		h.repo = filepath.Base(top)
+	}
+	if branch, err := git.CurrentBranch(ctx, "."); err == nil && branch != "HEAD" {
+		h.branch = branch
+	}
+	return h
+}
+
+func (h header) View() string {
+	s := lipgloss.NewStyle().Bold(true).Render("GitMate: " + h.title)
+	switch {
+	case h.repo != "" && h.branch != "":
+		s += theme.muted().Render(fmt.Sprintf("  %s · %s", h.repo, h.branch))
+	case h.repo != "":
+		s += theme.muted().Render("  " + h.repo)
+	}
+	return s + "\n"
+}
+
+// ---------------- Step List ----------------
+
+type stepState int
+
+const (
+	stepPending stepState = iota
+	stepRunning
+	stepOK
+	stepSkipped
+	stepFailed
+)
+
+// stepList shows the steps of a workflow with a status icon each, and the
+// explanation and command of the step that runs or failed.
+type stepList struct {
+	steps  []workflow.Step
+	vars   map[string]string
+	states []stepState
+	notes  []string // why a step was skipped
+}
+
+func newStepList(w workflow.Workflow, vars map[string]string) stepList {
+	return stepList{
+		steps:  w.Steps,
+		vars:   vars,
+		states: make([]stepState, len(w.Steps)),
+		notes:  make([]string, len(w.Steps)),
+	}
+}
+
+// update follows the events of the workflow run.
+func (l stepList) update(e workflowMsg) stepList {
+	if e.Step < 0 || e.Step >= len(l.states) {
+		// rollbacks after the run report no step of it
+		return l
+	}
+	switch e.Kind {
+	case workflow.StepStarted:
+		l.states[e.Step] = stepRunning
+	case workflow.StepDone:
+		l.states[e.Step] = stepOK
+	case workflow.StepSkipped:
+		l.states[e.Step] = stepSkipped
+		l.notes[e.Step] = "skipped: " + e.Err.Error()
+	case workflow.StepFailed:
+		l.states[e.Step] = stepFailed
+	}
+	return l
+}
+
+// rollbacks lists the rollbacks of the steps that completed, newest first.
+func (l stepList) rollbacks() []workflow.Undo {
+	var done []int
+	for i, st := range l.states {
+		if st == stepOK {
+			done = append(done, i)
+		}
+	}
+	return workflow.Rollbacks(l.steps, done, l.vars)
+}
+
+// View renders the steps; running is the icon of the running step, e.g. a spinner.
+func (l stepList) View(running string) string {
+	dim := theme.muted()
+	icons := map[stepState]string{
+		stepPending: dim.Render("○"),
+		stepRunning: running,
+		stepOK:      theme.good().Render("✓"),
+		stepSkipped: dim.Render("–"),
+		stepFailed:  theme.bad().Render("✗"),
+	}
+	s := ""
+	for i, step := range l.steps {
+		s += fmt.Sprintf("%s %s\n", icons[l.states[i]], step.Name)
+		if l.states[i] == stepRunning || l.states[i] == stepFailed {
+			if step.Explain != "" {
+				s += "    " + dim.Render(step.Explain) + "\n"
+			}
+			if cmd := step.Describe(l.vars); cmd != "" {
+				s += "    " + dim.Render("$ "+cmd) + "\n"
+			}
+		}
+		if l.notes[i] != "" {
+			s += "    " + dim.Render(l.notes[i]) + "\n"
+		}
+	}
+	return s
+}
+
+// ---------------- Confirm Dialog ----------------
+
+// confirmDialog asks a yes/no question below body: by default y or enter says
+// yes and n, esc, q or ctrl+c say no.
+type confirmDialog struct {
+	body     string
+	question string
+	help     helpOverlay
+	done     bool
+	yes      bool
+}
+
+func (m confirmDialog) Init() tea.Cmd { return nil }
+
+func (m confirmDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		var handled bool
+		if m.help, handled = m.help.update(msg); handled {
+			return m, nil
+		}
+		switch {
+		case key.Matches(msg, keys.Yes, keys.Select):
+			m.yes = true
+			m.done = true
+			return m, tea.Quit
+		case key.Matches(msg, keys.No, keys.Cancel, keys.Quit, interrupt):
+			m.done = true
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+func (m confirmDialog) View() string {
+	switch {
+	case m.done:
+		return ""
+	case m.help.open:
+		return m.help.View([]key.Binding{keys.Yes, keys.Select}, []key.Binding{keys.No, keys.Cancel, keys.Quit})
+	}
+	return m.body + theme.accent().Bold(true).Render(m.question) + " " + shortHelp(keys.Yes, keys.No, keys.Help)
+}
+
+// confirm shows a confirmDialog and returns the answer.
+func confirm(body, question string) (bool, error) {
+	final, err := show(confirmDialog{body: body, question: question})
+	if err != nil {
+		return false, err
+	}
+	m, ok := final.(confirmDialog)
+	return ok && m.yes, nil
+}
+
+// ---------------- Error Panel ----------------
+
+// errorHints say what to do next for the kinds of failure that have an
+// obvious next step.
+var errorHints = map[failure.Kind]string{
+	failure.Dirty:    "Commit or stash your changes, or rerun with --on-dirty.",
+	failure.Conflict: "Run `git status` to see the conflicted files.",
+	failure.Remote:   "Check your network connection and your access to the remote, then try again.",
+	failure.Checks:   "Run `gitmate checks` for the details.",
+	failure.Git:      "The full git output is in `gitmate logs`.",
+	failure.Unknown:  "The full output is in `gitmate logs`.",
+}
+
+// errorPanel renders err in a box with a hint for its kind of failure.
+func errorPanel(err error) string {
+	s := theme.bad().Bold(true).Render("Error: ") + err.Error()
+	if hint, ok := errorHints[failure.KindOf(err)]; ok {
+		s += "\n" + theme.muted().Render(hint)
+	}
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Error).
+		Padding(0, 1).
+		Width(78).
+		Render(s) + "\n"
+}
@@ -0,0 +1,206 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package tui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/failure"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/git"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// RunMode holds the global flags that decide how commands interact with the user.
+type RunMode struct {
+	// Headless runs orchestration with plain line logging instead of a TUI. It is
+	// set by --no-tui and automatically when not attached to a terminal.
+	Headless bool
+	// OnDirty answers the uncommitted-changes prompt: "stash", "commit", "discard"
+	// or "abort". Empty asks in the TUI and aborts when headless.
+	OnDirty string
+	// Yes answers confirmation prompts with yes.
+	Yes bool
+}
+
+// DirtyActions are the accepted --on-dirty values.
+var DirtyActions = []string{"stash", "commit", "discard", "abort"}
+
+var mode RunMode
+
+// SetRunMode configures how the commands of this package interact.
+func SetRunMode(m RunMode) { mode = m }
+
+// sender receives orchestration messages: a running *tea.Program, or the line
+// logger used in headless mode.
+type sender interface {
+	Send(msg tea.Msg)
+}
+
+// runProgram runs model m while orchestrate drives it from the background, like
+// tea.NewProgram(m).Run() with `go orchestrate(ctx, p)`. Headless, the same
+// orchestration runs without a terminal and its log lines are printed as they come.
+// orchestrate may be nil for models that only use commands.
+//
+// ctx ends the program and everything orchestrate runs. When the user quits the
+// TUI before the orchestration finished, its git command is stopped and waited
+// for, and the error is failure.Interrupted.
+func runProgram(ctx context.Context, m tea.Model, orchestrate func(context.Context, sender), opts ...tea.ProgramOption) (tea.Model, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if mode.Headless {
+		m = runHeadless(ctx, m, orchestrate)
+		if ctx.Err() != nil {
+			return m, interrupted()
+		}
+		return m, nil
+	}
+
+	if shell != nil {
+		return shell.run(ctx, m, orchestrate)
+	}
+
+	p := tea.NewProgram(m, append(opts, tea.WithContext(ctx))...)
+	if orchestrate == nil {
+		final, err := p.Run()
+		if errors.Is(err, tea.ErrProgramKilled) {
+			err = interrupted()
+		}
+		return final, err
+	}
+	s := &endSender{sender: p}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		orchestrate(ctx, s)
+	}()
+	final, err := p.Run()
+	if !s.ended.Load() {
+		// quit while git was still running: stop it before returning
+		cancel()
+		<-done
+		if err == nil || errors.Is(err, tea.ErrProgramKilled) {
+			err = interrupted()
+		}
+	}
+	return final, err
+}
+
+func interrupted() error {
+	return failure.Errorf(failure.Interrupted, "interrupted; the running command was stopped")
+}
+
+// endSender notes when the orchestration sent its final message.
+type endSender struct {
+	sender
+	ended atomic.Bool
+}
+
+func (s *endSender) Send(msg tea.Msg) {
+	switch msg.(type) {
+	case gitDoneMsg, gitErrMsg:
+		s.ended.Store(true)
+	}
+	s.sender.Send(msg)
+}
+
+// lineSender collects messages for runHeadless; sends after it returned are dropped.
+type lineSender struct {
+	msgs chan tea.Msg
+	done chan struct{}
+}
+
+func (s lineSender) Send(msg tea.Msg) {
+	select {
+	case s.msgs <- msg:
+	case <-s.done:
+	}
+}
+
+// runHeadless feeds messages through m's Update like Bubble Tea would, executing
+// its commands, until the model quits or the orchestration finishes. Without an
+// orchestration it also stops when ctx ends; otherwise the orchestration is
+// left to report that.
+func runHeadless(ctx context.Context, m tea.Model, orchestrate func(context.Context, sender)) tea.Model {
+	s := lineSender{msgs: make(chan tea.Msg), done: make(chan struct{})}
+	defer close(s.done)
+
+	exec := func(cmd tea.Cmd) {
+		if cmd != nil {
+			go func() { s.Send(cmd()) }()
+		}
+	}
+	exec(m.Init())
+	stop := ctx.Done()
+	if orchestrate != nil {
+		stop = nil
+		go orchestrate(ctx, s)
+	}
+
+	for {
+		var msg tea.Msg
+		select {
+		case msg = <-s.msgs:
+		case <-stop:
+			return m
+		}
+
+		switch msg := msg.(type) {
+		case nil, spinner.TickMsg:
+			// nothing animates without a terminal
+			continue
+		case tea.QuitMsg:
+			return m
+		case tea.BatchMsg:
+			for _, cmd := range msg {
+				exec(cmd)
+			}
+			continue
+		case stepMsg:
+			output.step(msg.command)
+		case stepDoneMsg:
+			output.stepDone(msg.command, msg.duration, msg.exitCode)
+		case gitLineMsg:
+			// of git's --progress updates only the last of each phase is worth a line
+			if p, ok := git.ParseProgress(strings.TrimPrefix(string(msg), "[stderr] ")); !ok || p.Done {
+				output.line(string(msg))
+			}
+		}
+
+		var cmd tea.Cmd
+		m, cmd = m.Update(msg)
+		exec(cmd)
+
+		switch msg.(type) {
+		case gitDoneMsg, gitErrMsg:
+			return m
+		}
+	}
+}
+
+// finished returns the error an orchestration model ended with. The TUI already
+// displayed it, so it is marked as shown; headless, it is left to the caller to print.
+func finished(err error) error {
+	if err == nil || mode.Headless {
+		return err
+	}
+	return failure.Shown(err)
+}
+
+// remoteFailure classifies an error of the hosting service as Remote, unless it
+// already has a kind, e.g. Timeout.
+func remoteFailure(err error) error {
+	if failure.KindOf(err) != failure.Unknown {
+		return err
+	}
+	return failure.New(failure.Remote, err)
+}
+
+// needsAnswer is the error for a prompt that can't be shown in headless mode.
+func needsAnswer(what, flag string) error {
+	return failure.Errorf(failure.Usage, "%s needs an answer; pass %s or run in a terminal", what, flag)
+}
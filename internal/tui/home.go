@@ -0,0 +1,165 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package tui
+
+import (
+	"context"
+	"slices"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/config"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/failure"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ---------------- Home Menu ----------------
+
+type homeItem struct {
+	title, desc string
+	run         func(context.Context) error
+}
+
+func (i homeItem) FilterValue() string { return i.title }
+func (i homeItem) Title() string       { return i.title }
+func (i homeItem) Description() string { return i.desc }
+
+// homeItems are the workflows of the menu: the builtin ones, then those of
+// .gitmate.yml that take no arguments.
+func homeItems(ctx context.Context) []list.Item {
+	items := []list.Item{
+		homeItem{"Start", "Start a feature branch from the trunk", func(ctx context.Context) error { return RunStartTUI(ctx, "") }},
+		homeItem{"Sync", "Rebase the branch onto the latest trunk", RunSyncTUI},
+		homeItem{"Switch", "Switch to another branch", func(ctx context.Context) error { return RunSwitchTUI(ctx, "") }},
+		homeItem{"Status", "Branch, upstream and CI at a glance", RunStatusTUI},
+		homeItem{"Ready", "Check the branch is ready for review", func(ctx context.Context) error { return RunReadyTUI(ctx) }},
+		homeItem{"Clean", "Squash fixup and WIP commits", RunCleanTUI},
+		homeItem{"Push", "Push the branch, setting its upstream", RunPushTUI},
+		homeItem{"Pull request", "Open or update the pull request", func(ctx context.Context) error { return RunPRTUI(ctx, PROptions{}) }},
+		homeItem{"Checks", "Show the CI checks of HEAD", func(ctx context.Context) error { return RunChecksTUI(ctx, ChecksOptions{}) }},
+		homeItem{"Prune branches", "Delete merged, gone and stale branches", func(ctx context.Context) error {
+			return RunPruneTUI(ctx, PruneOptions{StaleDays: 30})
+		}},
+		homeItem{"Tutor", "Learn git with interactive lessons", func(ctx context.Context) error { return RunTutorTUI(ctx, "", TutorOptions{}) }},
+	}
+	cfg, err := config.Load(ctx, ".")
+	if err != nil {
+		return items
+	}
+	names := make([]string, 0, len(cfg.Workflows))
+	for name, w := range cfg.Workflows {
+		if len(w.Args) == 0 {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		desc := cfg.Workflows[name].Short
+		if desc == "" {
+			desc = "Workflow from " + config.FileName
+		}
+		items = append(items, homeItem{name, desc, func(ctx context.Context) error { return RunWorkflowTUI(ctx, name, nil) }})
+	}
+	return items
+}
+
+type homeModel struct {
+	list   list.Model
+	choice *homeItem
+}
+
+func newHomeModel(ctx context.Context) homeModel {
+	return homeModel{list: newList(homeItems(ctx), "GitMate", 80, 20)}
+}
+
+func (m homeModel) Init() tea.Cmd { return nil }
+
+func (m homeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.list.SetSize(msg.Width, msg.Height-1)
+	case tea.KeyMsg:
+		if m.list.FilterState() == list.Filtering {
+			break
+		}
+		switch {
+		case key.Matches(msg, keys.Select):
+			if i, ok := m.list.SelectedItem().(homeItem); ok {
+				m.choice = &i
+				return m, tea.Quit
+			}
+		case key.Matches(msg, keys.Quit, interrupt):
+			return m, tea.Quit
+		}
+	}
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m homeModel) View() string {
+	return m.list.View()
+}
+
+// ---------------- Error Screen ----------------
+
+// errorScreen shows an error no screen of the workflow showed, e.g. that the
+// directory is no repository, until a key is pressed.
+type errorScreen struct {
+	err  error
+	done bool
+}
+
+func (m errorScreen) Init() tea.Cmd { return nil }
+
+func (m errorScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if _, ok := msg.(tea.KeyMsg); ok {
+		m.done = true
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m errorScreen) View() string {
+	if m.done {
+		return errorPanel(m.err)
+	}
+	return errorPanel(m.err) + theme.muted().Render("press any key to go back")
+}
+
+// ---------------- Public Entry ----------------
+
+// RunHomeTUI opens the menu of workflows and returns to it after each one,
+// all in one program. ctrl+c leaves GitMate from any screen.
+func RunHomeTUI(ctx context.Context) error {
+	if mode.Headless {
+		say("Run `gitmate --help` to see available commands\n")
+		return nil
+	}
+	return startApp(ctx, true, home)
+}
+
+func home(ctx context.Context) error {
+	for {
+		final, err := show(fullScreen{newHomeModel(ctx)})
+		if err != nil {
+			return err
+		}
+		m, ok := final.(homeModel)
+		if !ok || m.choice == nil {
+			return nil
+		}
+		err = m.choice.run(ctx)
+		switch {
+		case ctx.Err() != nil:
+			return interrupted()
+		case err == nil, failure.IsShown(err), failure.KindOf(err) == failure.Interrupted:
+			// quitting a workflow part way leads back to the menu
+		default:
+			if _, err := show(errorScreen{err: err}); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,283 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package tui
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/config"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/failure"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ---------------- Key Map ----------------
+
+// keyMap holds the key bindings of every screen. Screens match keys against
+// it and build their help from it, so rebinding an action changes both.
+type keyMap struct {
+	Quit, Help, Up, Down, Select, Cancel, Search, Yes, No key.Binding
+
+	Toggle, All key.Binding // prune-branches
+	Refresh     key.Binding // status
+
+	LogExpand, LogNext, LogPrev, LogCopy key.Binding
+
+	TutorNext, TutorPrev, TutorHint, TutorCheck, TutorKeep key.Binding
+
+	PRField, PRDraft, PRReviewers, PRSubmit key.Binding
+
+	FixSync, FixClean, FixRename, FixPush key.Binding // ready
+}
+
+// interrupt quits every screen whatever the key map says, so there is always
+// a way out.
+var interrupt = key.NewBinding(key.WithKeys("ctrl+c"), key.WithHelp("ctrl+c", "quit"))
+
+// answerKeys answer the questions of the tutor by number.
+var answerKeys = key.NewBinding(key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9"), key.WithHelp("1-9", "answer"))
+
+func binding(desc string, ks ...string) key.Binding {
+	return key.NewBinding(key.WithKeys(ks...), key.WithHelp(keyLabel(ks), desc))
+}
+
+func defaultKeys() keyMap {
+	return keyMap{
+		Quit:   binding("quit", "q"),
+		Help:   binding("toggle help", "?"),
+		Up:     binding("up", "up", "k"),
+		Down:   binding("down", "down", "j"),
+		Select: binding("select", "enter"),
+		Cancel: binding("cancel", "esc"),
+		Search: binding("search", "/"),
+		Yes:    binding("yes", "y"),
+		No:     binding("no", "n"),
+
+		Toggle:  binding("toggle", " ", "x"),
+		All:     binding("select all", "a"),
+		Refresh: binding("refresh", "r"),
+
+		LogExpand: binding("expand log", "l"),
+		LogNext:   binding("next match", "n"),
+		LogPrev:   binding("previous match", "N"),
+		LogCopy:   binding("copy log", "c"),
+
+		TutorNext:  binding("next step", "n", "right"),
+		TutorPrev:  binding("previous step", "p", "left"),
+		TutorHint:  binding("hint", "h"),
+		TutorCheck: binding("check", "c"),
+		TutorKeep:  binding("keep sandbox", "k"),
+
+		PRField:     binding("switch field", "tab", "shift+tab"),
+		PRDraft:     binding("toggle draft", "ctrl+d"),
+		PRReviewers: binding("toggle suggested reviewers", "ctrl+r"),
+		PRSubmit:    binding("submit", "ctrl+s"),
+
+		FixSync:   binding("sync", "s"),
+		FixClean:  binding("clean", "c"),
+		FixRename: binding("rename", "b"),
+		FixPush:   binding("push", "p"),
+	}
+}
+
+// Keymaps are the presets of ui.keymap, as changes to the default bindings.
+var Keymaps = map[string]map[string][]string{
+	"default": {},
+	"vim": {
+		"log.expand": {"z"},
+		"log.copy":   {"Y"}, // y answers yes
+		"tutor.next": {"l", "n", "right"},
+		"tutor.prev": {"h", "p", "left"},
+		"tutor.hint": {"K"},
+	},
+	"emacs": {
+		"up":         {"up", "ctrl+p"},
+		"down":       {"down", "ctrl+n"},
+		"cancel":     {"esc", "ctrl+g"},
+		"search":     {"/", "ctrl+s"},
+		"log.prev":   {"N", "ctrl+r"},
+		"log.copy":   {"c", "alt+w"},
+		"tutor.next": {"n", "right", "ctrl+f"},
+		"tutor.prev": {"p", "left", "ctrl+b"},
+	},
+}
+
+// screens lists the actions each screen listens to. A key can do only one
+// thing per screen, which SetKeys checks after rebinding.
+var screens = map[string][]string{
+	"log":     {"quit", "help", "up", "down", "select", "cancel", "search", "log.expand", "log.next", "log.prev", "log.copy"},
+	"confirm": {"yes", "no", "select", "cancel", "quit", "help"},
+	"list":    {"up", "down", "select", "search", "quit", "help"},
+	"prune":   {"up", "down", "toggle", "all", "select", "quit", "help"},
+	"status":  {"refresh", "quit", "help"},
+	"tutor":   {"tutor.next", "tutor.prev", "tutor.hint", "tutor.check", "tutor.keep", "select", "quit", "help"},
+	"pr":      {"pr.field", "pr.draft", "pr.reviewers", "pr.submit", "cancel", "quit"},
+	"ready":   {"fix.sync", "fix.clean", "fix.rename", "fix.push", "select", "quit", "help"},
+}
+
+// keys is the key map in use, chosen by SetKeys.
+var keys = defaultKeys()
+
+// bindings names the actions that can be rebound in ui.keys.
+func (k *keyMap) bindings() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"quit": &k.Quit, "help": &k.Help, "up": &k.Up, "down": &k.Down,
+		"select": &k.Select, "cancel": &k.Cancel, "search": &k.Search,
+		"yes": &k.Yes, "no": &k.No,
+		"toggle": &k.Toggle, "all": &k.All, "refresh": &k.Refresh,
+		"log.expand": &k.LogExpand, "log.next": &k.LogNext, "log.prev": &k.LogPrev, "log.copy": &k.LogCopy,
+		"tutor.next": &k.TutorNext, "tutor.prev": &k.TutorPrev, "tutor.hint": &k.TutorHint,
+		"tutor.check": &k.TutorCheck, "tutor.keep": &k.TutorKeep,
+		"pr.field": &k.PRField, "pr.draft": &k.PRDraft, "pr.reviewers": &k.PRReviewers, "pr.submit": &k.PRSubmit,
+		"fix.sync": &k.FixSync, "fix.clean": &k.FixClean, "fix.rename": &k.FixRename, "fix.push": &k.FixPush,
+	}
+}
+
+// SetKeys applies the keymap preset and the rebound keys configured under ui:.
+// An unknown preset or action, or a key bound twice on a screen, is a usage error.
+func SetKeys(cfg config.UIConfig) error {
+	name := cfg.Keymap
+	if name == "" {
+		name = "default"
+	}
+	preset, ok := Keymaps[name]
+	if !ok {
+		return failure.Errorf(failure.Usage, "unknown keymap %q: want default, vim or emacs", name)
+	}
+	k := defaultKeys()
+	b := k.bindings()
+	for _, changes := range []map[string][]string{preset, cfg.Keys} {
+		for action, ks := range changes {
+			target, ok := b[action]
+			if !ok {
+				return failure.Errorf(failure.Usage, "unknown key action %q under ui.keys: want one of %s",
+					action, strings.Join(slices.Sorted(maps.Keys(b)), ", "))
+			}
+			rebind(target, ks)
+		}
+	}
+	if err := checkScreens(b); err != nil {
+		return err
+	}
+	keys = k
+	return nil
+}
+
+// checkScreens reports a key bound to two actions of the same screen.
+func checkScreens(b map[string]*key.Binding) error {
+	for _, screen := range slices.Sorted(maps.Keys(screens)) {
+		owner := map[string]string{}
+		for _, action := range screens[screen] {
+			if !b[action].Enabled() {
+				continue
+			}
+			for _, k := range b[action].Keys() {
+				if other, ok := owner[k]; ok {
+					return failure.Errorf(failure.Usage, "key %q is bound to both %s and %s on the %s screen; rebind one under ui.keys",
+						k, other, action, screen)
+				}
+				owner[k] = action
+			}
+		}
+	}
+	return nil
+}
+
+// rebind sets the keys of b, keeping its description; no keys disable it.
+func rebind(b *key.Binding, ks []string) {
+	b.SetKeys(ks...)
+	b.SetHelp(keyLabel(ks), b.Help().Desc)
+	b.SetEnabled(len(ks) > 0)
+}
+
+// keyLabel is how keys are shown in the help, e.g. "↑/k".
+func keyLabel(ks []string) string {
+	names := map[string]string{"up": "↑", "down": "↓", "left": "←", "right": "→", " ": "space"}
+	labels := make([]string, len(ks))
+	for i, k := range ks {
+		if n, ok := names[k]; ok {
+			k = n
+		}
+		labels[i] = k
+	}
+	return strings.Join(labels, "/")
+}
+
+// ---------------- Help ----------------
+
+// newHelp is a help view in the colors of the theme.
+func newHelp() help.Model {
+	h := help.New()
+	k, d := theme.accent(), theme.muted()
+	h.Styles.ShortKey, h.Styles.FullKey = k, k
+	h.Styles.ShortDesc, h.Styles.FullDesc = d, d
+	h.Styles.ShortSeparator, h.Styles.FullSeparator, h.Styles.Ellipsis = d, d, d
+	return h
+}
+
+// shortHelp is a line of hints for bindings, e.g. "q quit • ? toggle help".
+func shortHelp(bindings ...key.Binding) string {
+	return newHelp().ShortHelpView(bindings)
+}
+
+// helpOverlay is the ? overlay of a screen, listing its key bindings.
+type helpOverlay struct {
+	open bool
+}
+
+// update opens and closes the overlay. It reports whether it took msg; while
+// open, it takes every key but ctrl+c so the screen underneath stays put.
+func (h helpOverlay) update(msg tea.KeyMsg) (helpOverlay, bool) {
+	switch {
+	case key.Matches(msg, interrupt):
+		return h, false
+	case h.open:
+		if key.Matches(msg, keys.Help, keys.Cancel, keys.Quit) {
+			h.open = false
+		}
+		return h, true
+	case key.Matches(msg, keys.Help):
+		h.open = true
+		return h, true
+	}
+	return h, false
+}
+
+// View renders the bindings in columns, one per group.
+func (h helpOverlay) View(groups ...[]key.Binding) string {
+	s := lipgloss.NewStyle().Bold(true).Render("Keys") + "\n\n"
+	s += newHelp().FullHelpView(groups) + "\n\n"
+	s += theme.muted().Render(fmt.Sprintf("%s or %s to close", keys.Help.Help().Key, keys.Cancel.Help().Key))
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Accent).
+		Padding(0, 1).
+		Render(s) + "\n"
+}
+
+// ---------------- Lists ----------------
+
+// newList is a list in the colors of the theme, moved with the key map. The
+// list has its own ? help, which shows enter to select with the list's keys.
+func newList(items []list.Item, title string, width, height int) list.Model {
+	l := list.New(items, theme.listDelegate(), width, height)
+	l.Title = title
+	l.KeyMap.CursorUp = keys.Up
+	l.KeyMap.CursorDown = keys.Down
+	l.KeyMap.Filter = keys.Search
+	l.KeyMap.ShowFullHelp = keys.Help
+	l.KeyMap.CloseFullHelp = keys.Help
+	l.KeyMap.Quit = keys.Quit
+	l.KeyMap.ForceQuit = interrupt
+	selectKeys := func() []key.Binding { return []key.Binding{keys.Select} }
+	l.AdditionalShortHelpKeys = selectKeys
+	l.AdditionalFullHelpKeys = selectKeys
+	return l
+}
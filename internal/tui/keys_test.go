@@ -0,0 +1,78 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package tui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/config"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/failure"
+)
+
+func TestSetKeys(t *testing.T) {
+	tests := []struct {
+		name   string
+		ui     config.UIConfig
+		errHas string // "" when the keys are accepted
+	}{
+		{name: "default", ui: config.UIConfig{}},
+		{name: "vim", ui: config.UIConfig{Keymap: "vim"}},
+		{name: "emacs", ui: config.UIConfig{Keymap: "emacs"}},
+		{name: "unknown keymap", ui: config.UIConfig{Keymap: "nano"}, errHas: `unknown keymap "nano"`},
+		{name: "unknown action", ui: config.UIConfig{Keys: map[string][]string{"jump": {"J"}}}, errHas: `unknown key action "jump"`},
+		{name: "rebound", ui: config.UIConfig{Keys: map[string][]string{"log.copy": {"C"}}}},
+		// n is also no and log.next, but on other screens
+		{name: "same key on other screens", ui: config.UIConfig{Keys: map[string][]string{"refresh": {"n"}}}},
+		{
+			name:   "same key on one screen",
+			ui:     config.UIConfig{Keys: map[string][]string{"log.copy": {"y", "l"}}},
+			errHas: `key "l" is bound to both log.expand and log.copy on the log screen`,
+		},
+		{
+			name:   "clash with the preset",
+			ui:     config.UIConfig{Keymap: "vim", Keys: map[string][]string{"log.expand": {"Y"}}},
+			errHas: `key "Y" is bound to both log.expand and log.copy on the log screen`,
+		},
+		{name: "disabled", ui: config.UIConfig{Keys: map[string][]string{"log.expand": {}, "log.copy": {"l"}}}},
+	}
+	t.Cleanup(func() { keys = defaultKeys() })
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := SetKeys(tt.ui)
+			switch {
+			case tt.errHas == "" && err != nil:
+				t.Errorf("SetKeys = %v, want the keys accepted", err)
+			case tt.errHas != "" && (err == nil || !strings.Contains(err.Error(), tt.errHas)):
+				t.Errorf("SetKeys = %v, want an error containing %q", err, tt.errHas)
+			case err != nil && failure.KindOf(err) != failure.Usage:
+				t.Errorf("SetKeys = %v (exit %d), want a usage error", err, failure.ExitCode(err))
+			}
+		})
+	}
+}
+
+// TestVimKeysUnique checks that the keys the vim preset adds are used by no
+// other binding, whatever the screen: vim users expect them to mean one thing.
+func TestVimKeysUnique(t *testing.T) {
+	defaults := defaultKeys()
+	k := defaultKeys()
+	b := k.bindings()
+	for action, ks := range Keymaps["vim"] {
+		rebind(b[action], ks)
+	}
+	for action, ks := range Keymaps["vim"] {
+		for _, key := range ks {
+			if slices.Contains(defaults.bindings()[action].Keys(), key) {
+				continue
+			}
+			for other, binding := range b {
+				if other != action && slices.Contains(binding.Keys(), key) {
+					t.Errorf("%s takes %q, which %s uses too", action, key, other)
+				}
+			}
+		}
+	}
+}
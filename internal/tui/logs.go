@@ -0,0 +1,44 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/sessionlog"
+)
+
+// LogsOptions are the flags of `gitmate logs`.
+type LogsOptions struct {
+	Sessions int  // how many of the latest sessions to print; 0 for all
+	Path     bool // print where the log is instead
+}
+
+// RunLogs prints the session log for bug reports.
+func RunLogs(opts LogsOptions) error {
+	path, err := sessionlog.Path()
+	if err != nil {
+		return err
+	}
+	if opts.Path {
+		output.data(map[string]any{"path": path})
+		say("%s\n", path)
+		return nil
+	}
+	sessions, err := sessionlog.Sessions(opts.Sessions)
+	if err != nil {
+		return err
+	}
+	output.data(map[string]any{"path": path, "sessions": sessions})
+	if jsonOutput() {
+		return nil
+	}
+	if len(sessions) == 0 {
+		fmt.Printf("Nothing logged yet in %s.\n", path)
+		return nil
+	}
+	fmt.Print(strings.Join(sessions, "\n"))
+	return nil
+}
@@ -0,0 +1,257 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ---------------- Log View ----------------
+
+// logLines is how many lines a collapsed log shows.
+const logLines = 10
+
+// logView is a scrollable, searchable view of command output. Collapsed it
+// shows the latest lines; l expands it to the height of the terminal. With the
+// default keys:
+//
+//	↑/↓ pgup/pgdn  scroll (following the end until scrolled up)
+//	/              search; enter to jump to the first match, esc to cancel
+//	n / N          next / previous match
+//	c              copy the whole log to the clipboard
+//	l              expand or collapse
+type logView struct {
+	lines     []string
+	view      viewport.Model
+	expanded  bool
+	maxHeight int // height of the expanded log
+	input     textinput.Model
+	searching bool // the search input has the keys
+	query     string
+	matches   []int // lines containing query
+	match     int   // index into matches of the current match
+	status    string
+}
+
+func newLogView() logView {
+	in := textinput.New()
+	in.Prompt = "/"
+	in.Placeholder = "search the log"
+	view := viewport.New(80, 0)
+	view.KeyMap.Up, view.KeyMap.Down = keys.Up, keys.Down
+	return logView{view: view, maxHeight: 20, input: in}
+}
+
+// keys are the bindings of the log when it isn't searching.
+func (l logView) keys() []key.Binding {
+	expand := keys.LogExpand
+	if l.expanded {
+		expand.SetHelp(expand.Help().Key, "collapse log")
+	}
+	return []key.Binding{keys.Up, keys.Down, expand, keys.Search, keys.LogCopy}
+}
+
+// capturing reports whether the log is taking typed text, so models must not
+// treat keys like q as commands.
+func (l logView) capturing() bool { return l.searching }
+
+func (l logView) update(msg tea.Msg) (logView, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		l.view.Width = msg.Width
+		l.maxHeight = max(msg.Height-12, logLines)
+		l.refresh()
+		return l, nil
+	case tea.KeyMsg:
+		if l.searching {
+			return l.searchKey(msg)
+		}
+		l.status = ""
+		switch {
+		case key.Matches(msg, keys.LogExpand):
+			l.expanded = !l.expanded
+			l.refresh()
+			l.view.GotoBottom()
+			return l, nil
+		case key.Matches(msg, keys.Search):
+			l.searching = true
+			l.input.SetValue("")
+			return l, l.input.Focus()
+		case key.Matches(msg, keys.LogNext, keys.LogPrev):
+			if len(l.matches) > 0 {
+				step := 1
+				if key.Matches(msg, keys.LogPrev) {
+					step = len(l.matches) - 1
+				}
+				l.match = (l.match + step) % len(l.matches)
+				l.showMatch()
+			}
+			return l, nil
+		case key.Matches(msg, keys.Cancel):
+			l.query, l.matches = "", nil
+			l.refresh()
+			return l, nil
+		case key.Matches(msg, keys.LogCopy):
+			if err := clipboard.WriteAll(strings.Join(l.lines, "\n")); err != nil {
+				l.status = "could not copy the log: " + err.Error()
+			} else {
+				l.status = fmt.Sprintf("copied %d lines to the clipboard", len(l.lines))
+			}
+			return l, nil
+		}
+		var cmd tea.Cmd
+		l.view, cmd = l.view.Update(msg)
+		return l, cmd
+	}
+	return l, nil
+}
+
+func (l logView) searchKey(msg tea.KeyMsg) (logView, tea.Cmd) {
+	switch {
+	case key.Matches(msg, keys.Select):
+		l.searching = false
+		l.input.Blur()
+		l.query = l.input.Value()
+		l.refresh()
+		l.match = 0
+		if len(l.matches) == 0 && l.query != "" {
+			l.status = "no match for " + l.query
+		}
+		l.showMatch()
+		return l, nil
+	case key.Matches(msg, keys.Cancel):
+		l.searching = false
+		l.input.Blur()
+		return l, nil
+	}
+	var cmd tea.Cmd
+	l.input, cmd = l.input.Update(msg)
+	return l, cmd
+}
+
+// add appends a line.
+func (l *logView) add(line string) {
+	l.lines = append(l.lines, line)
+	l.refresh()
+}
+
+// replaceLast replaces the last line, e.g. with a newer progress update.
+func (l *logView) replaceLast(line string) {
+	if len(l.lines) == 0 {
+		l.add(line)
+		return
+	}
+	l.lines[len(l.lines)-1] = line
+	l.refresh()
+}
+
+// showMatch scrolls the current match into view.
+func (l *logView) showMatch() {
+	if len(l.matches) == 0 {
+		return
+	}
+	l.refresh()
+	line := l.matches[l.match]
+	if line < l.view.YOffset || line >= l.view.YOffset+l.view.Height {
+		l.view.SetYOffset(line - l.view.Height/2)
+	}
+}
+
+// refresh renders the lines into the viewport, following the end unless the
+// user scrolled up.
+func (l *logView) refresh() {
+	follow := l.view.AtBottom() || l.view.Height == 0
+	height := logLines
+	if l.expanded {
+		height = l.maxHeight
+	}
+	l.view.Height = min(len(l.lines), height)
+
+	stderr := theme.warn()
+	warn := theme.bad()
+	hit := lipgloss.NewStyle().Reverse(true)
+	current := -1
+	if len(l.matches) > 0 {
+		current = l.matches[l.match%len(l.matches)]
+	}
+
+	l.matches = nil
+	rendered := make([]string, len(l.lines))
+	for i, line := range l.lines {
+		found := l.query != "" && strings.Contains(strings.ToLower(line), strings.ToLower(l.query))
+		if found {
+			l.matches = append(l.matches, i)
+		}
+		switch {
+		case found:
+			line = highlight(line, l.query, hit)
+			if i == current {
+				line = "› " + line
+			}
+		case strings.HasPrefix(line, "[stderr] "):
+			line = stderr.Render(line)
+		case strings.HasPrefix(line, "[warn] "):
+			line = warn.Render(line)
+		}
+		rendered[i] = line
+	}
+	l.view.SetContent(strings.Join(rendered, "\n"))
+	if follow {
+		l.view.GotoBottom()
+	}
+}
+
+// highlight renders the occurrences of query in line, ignoring case, with style.
+func highlight(line, query string, style lipgloss.Style) string {
+	lower, q := strings.ToLower(line), strings.ToLower(query)
+	if len(lower) != len(line) || len(q) != len(query) {
+		// lowering changed the byte offsets; match the case as typed
+		lower, q = line, query
+	}
+	var b strings.Builder
+	for {
+		i := strings.Index(lower, q)
+		if i < 0 {
+			break
+		}
+		b.WriteString(line[:i])
+		b.WriteString(style.Render(line[i : i+len(q)]))
+		line, lower = line[i+len(q):], lower[i+len(q):]
+	}
+	b.WriteString(line)
+	return b.String()
+}
+
+func (l logView) View() string {
+	if len(l.lines) == 0 && !l.searching {
+		return ""
+	}
+	dim := theme.muted()
+
+	s := l.view.View() + "\n"
+	switch {
+	case l.searching:
+		s += l.input.View() + "\n"
+	case l.status != "":
+		s += dim.Render(l.status) + "\n"
+	case l.query != "":
+		clear := keys.Cancel
+		clear.SetHelp(clear.Help().Key, "clear")
+		s += dim.Render(fmt.Sprintf("/%s: %d matches", l.query, len(l.matches))) + " " +
+			shortHelp(keys.LogNext, keys.LogPrev, clear) + "\n"
+	case len(l.lines) > logLines && !l.expanded:
+		s += dim.Render(fmt.Sprintf("%d lines", len(l.lines))) + " " + shortHelp(l.keys()...) + "\n"
+	case l.expanded:
+		s += shortHelp(l.keys()...) + "\n"
+	}
+	return s
+}
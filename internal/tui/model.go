@@ -1 +1,132 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
 package tui
+
+import (
+	"context"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/workflow"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ---------------- Run Model ----------------
+
+// runModel is what the models running git commands share: a header, the steps
+// of the workflow, a spinner while it runs, the output log and how it ended.
+// Models embed it, pass their messages to update and build their View from
+// its parts, or use view when they show nothing else.
+type runModel struct {
+	header     header
+	steps      stepList
+	spinner    spinner.Model
+	log        outputLog
+	help       helpOverlay
+	err        error
+	done       bool
+	quitOnDone bool // end the program when the run ends rather than on q
+}
+
+func newRunModel(ctx context.Context, title string, w workflow.Workflow, vars map[string]string) runModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	s.Style = theme.accent()
+	return runModel{
+		header:  newHeader(ctx, title),
+		steps:   newStepList(w, vars),
+		spinner: s,
+		log:     newOutputLog(),
+	}
+}
+
+func (m runModel) Init() tea.Cmd {
+	return m.spinner.Tick
+}
+
+func (m runModel) update(msg tea.Msg) (runModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if key.Matches(msg, interrupt) || key.Matches(msg, keys.Quit) && !m.log.capturing() && !m.help.open {
+			return m, tea.Quit
+		}
+		if !m.log.capturing() {
+			var handled bool
+			if m.help, handled = m.help.update(msg); handled {
+				return m, nil
+			}
+		}
+		var cmd tea.Cmd
+		m.log, cmd = m.log.update(msg)
+		return m, cmd
+	case tea.WindowSizeMsg, stepMsg, gitLineMsg:
+		var cmd tea.Cmd
+		m.log, cmd = m.log.update(msg)
+		return m, cmd
+	case spinner.TickMsg:
+		if !m.done {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	case workflowMsg:
+		m.steps = m.steps.update(msg)
+	case gitErrMsg:
+		m.err = msg
+		m.done = true
+		if m.quitOnDone {
+			return m, tea.Quit
+		}
+	case gitDoneMsg:
+		m.done = true
+		if m.quitOnDone {
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+// status is the line saying what runs, or the error panel or success once done.
+// rollbacks lists the rollbacks of the steps that completed, for rollbackAfter.
+func (m runModel) rollbacks() []workflow.Undo { return m.steps.rollbacks() }
+
+func (m runModel) status(running, success string) string {
+	switch {
+	case m.err != nil:
+		return errorPanel(m.err)
+	case m.done:
+		return theme.good().Render("✅ "+success) + "\n"
+	}
+	return m.spinner.View() + " " + running + "\n"
+}
+
+// footer tells how to leave once the run is over.
+func (m runModel) footer() string {
+	if !m.done {
+		return ""
+	}
+	return "\n" + shortHelp(keys.Quit, keys.Help)
+}
+
+// helpView is the header with the ? overlay, shown instead of the screen.
+func (m runModel) helpView() string {
+	return m.header.View() + "\n" + m.help.View(
+		[]key.Binding{keys.Quit, interrupt, keys.Help},
+		[]key.Binding{keys.Up, keys.Down, keys.LogExpand, keys.LogCopy},
+		[]key.Binding{keys.Search, keys.LogNext, keys.LogPrev, keys.Cancel},
+	)
+}
+
+// view lays the parts out: header, steps, status, log and footer.
+func (m runModel) view(running, success string) string {
+	if m.help.open {
+		return m.helpView()
+	}
+	s := m.header.View() + "\n"
+	if len(m.steps.steps) > 0 {
+		s += m.steps.View(m.spinner.View()) + "\n"
+	}
+	s += m.status(running, success) + "\n"
+	s += m.log.View()
+	return s + m.footer()
+}
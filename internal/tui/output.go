@@ -0,0 +1,258 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package tui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/failure"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/git"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/hosting"
+)
+
+// Output formats accepted by --output.
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+)
+
+// ---------------- Schema ----------------
+
+// Event is one JSON Lines record written with --output json. Every event has a
+// type and a time; the other fields depend on the type:
+//
+//	step      a command starts: command ("git", or "sh" for workflow scripts), args
+//	output    a line of its output: stream ("stdout" or "stderr"), text
+//	step_end  the command finished: command, args, durationMs, exitCode
+//	message   something GitMate would have printed: text
+//	warning   a problem that didn't stop the command: text
+//	result    the command finished: result
+type Event struct {
+	Type       string         `json:"type"`
+	Time       time.Time      `json:"time"`
+	Command    string         `json:"command,omitempty"`
+	Args       []string       `json:"args,omitempty"`
+	Stream     string         `json:"stream,omitempty"`
+	Text       string         `json:"text,omitempty"`
+	DurationMs *int64         `json:"durationMs,omitempty"`
+	ExitCode   *int           `json:"exitCode,omitempty"`
+	Result     *CommandResult `json:"result,omitempty"`
+}
+
+// CommandResult summarises a command run. It is the payload of the final event.
+type CommandResult struct {
+	Command      string       `json:"command"`
+	OK           bool         `json:"ok"`
+	Steps        []StepResult `json:"steps"`
+	Refs         *RefsResult  `json:"refs,omitempty"`
+	NoisyCommits []string     `json:"noisyCommits,omitempty"`
+	RolledBack   []string     `json:"rolledBack,omitempty"` // what was undone after a failure
+	Warnings     []string     `json:"warnings"`
+	Error        *ErrorResult `json:"error,omitempty"`
+	Data         any          `json:"data,omitempty"` // command specific, e.g. the status of `status`
+}
+
+// StepResult is one command that ran.
+type StepResult struct {
+	Command    string   `json:"command"`
+	Args       []string `json:"args"`
+	DurationMs int64    `json:"durationMs"`
+	ExitCode   int      `json:"exitCode"`
+}
+
+// RefsResult is where the repository stands after the command.
+type RefsResult struct {
+	Branch   string `json:"branch"`
+	Head     string `json:"head"`
+	Upstream string `json:"upstream,omitempty"`
+}
+
+// ErrorResult describes why a command failed.
+type ErrorResult struct {
+	Kind    string `json:"kind"`
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// checkResult is hosting.Check in the JSON schema.
+type checkResult struct {
+	Name        string     `json:"name"`
+	State       string     `json:"state"`
+	URL         string     `json:"url,omitempty"`
+	StartedAt   *time.Time `json:"startedAt,omitempty"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+}
+
+func checkResults(checks []hosting.Check) []checkResult {
+	res := []checkResult{}
+	for _, c := range checks {
+		r := checkResult{Name: c.Name, State: string(c.State), URL: c.URL}
+		if !c.StartedAt.IsZero() {
+			r.StartedAt = &c.StartedAt
+		}
+		if !c.CompletedAt.IsZero() {
+			r.CompletedAt = &c.CompletedAt
+		}
+		res = append(res, r)
+	}
+	return res
+}
+
+// ---------------- Recorder ----------------
+
+// recorder collects what a command did and, with --output json, streams it as
+// events. In text mode it prints the plain lines of headless mode.
+type recorder struct {
+	mu     sync.Mutex
+	enc    *json.Encoder
+	result CommandResult
+}
+
+var output = &recorder{}
+
+func (r *recorder) json() bool { return r.enc != nil }
+
+func (r *recorder) emit(e Event) {
+	e.Time = time.Now().UTC()
+	_ = r.enc.Encode(e)
+}
+
+// step records that a command started; command holds the program and its arguments.
+func (r *recorder) step(command []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.json() {
+		fmt.Println("$ " + strings.Join(command, " "))
+		return
+	}
+	r.emit(Event{Type: "step", Command: command[0], Args: command[1:]})
+}
+
+// stepDone records how a command ended.
+func (r *recorder) stepDone(command []string, d time.Duration, code int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ms := d.Milliseconds()
+	r.result.Steps = append(r.result.Steps,
+		StepResult{Command: command[0], Args: command[1:], DurationMs: ms, ExitCode: code})
+	if r.json() {
+		r.emit(Event{Type: "step_end", Command: command[0], Args: command[1:], DurationMs: &ms, ExitCode: &code})
+	}
+}
+
+// line records a line of git output. Lines starting with "[stderr] " came from
+// stderr and "[warn] " marks GitMate's own warnings.
+func (r *recorder) line(s string) {
+	if w, ok := strings.CutPrefix(s, "[warn] "); ok {
+		r.warn(w)
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.json() {
+		fmt.Println(s)
+		return
+	}
+	stream := "stdout"
+	if text, ok := strings.CutPrefix(s, "[stderr] "); ok {
+		stream, s = "stderr", text
+	}
+	r.emit(Event{Type: "output", Stream: stream, Text: s})
+}
+
+func (r *recorder) warn(s string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.result.Warnings = append(r.result.Warnings, s)
+	if !r.json() {
+		fmt.Println("warning: " + s)
+		return
+	}
+	r.emit(Event{Type: "warning", Text: s})
+}
+
+// data attaches the command specific payload of the result.
+func (r *recorder) data(v any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.result.Data = v
+}
+
+// noisy lists the noisy commits a command detected.
+func (r *recorder) noisy(commits []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.result.NoisyCommits = commits
+}
+
+// rolledBack records what a rollback undid.
+func (r *recorder) rolledBack(undone []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.result.RolledBack = undone
+}
+
+// say prints a message for the user; with --output json it becomes a message event.
+func say(format string, args ...any) {
+	output.mu.Lock()
+	defer output.mu.Unlock()
+	if !output.json() {
+		if !shell.println(fmt.Sprintf(format, args...)) {
+			fmt.Printf(format, args...)
+		}
+		return
+	}
+	text := strings.TrimRight(fmt.Sprintf(format, args...), "\n")
+	output.emit(Event{Type: "message", Text: text})
+}
+
+// jsonOutput reports whether --output json is in effect.
+func jsonOutput() bool { return output.json() }
+
+// ---------------- Public Entry ----------------
+
+// StartOutput selects the output format for the command about to run.
+func StartOutput(format, command string) {
+	output = &recorder{result: CommandResult{Command: command}}
+	if format == OutputJSON {
+		output.enc = json.NewEncoder(os.Stdout)
+	}
+}
+
+// FinishOutput ends the command: with --output json it writes the result event,
+// including the resulting refs and err with its kind.
+func FinishOutput(ctx context.Context, err error) {
+	// the refs are read even when the command was interrupted
+	ctx = context.WithoutCancel(ctx)
+	output.mu.Lock()
+	defer output.mu.Unlock()
+	if !output.json() {
+		return
+	}
+	res := output.result
+	res.OK = err == nil
+	if res.Steps == nil {
+		res.Steps = []StepResult{}
+	}
+	if res.Warnings == nil {
+		res.Warnings = []string{}
+	}
+	if branch, e := git.CurrentBranch(ctx, "."); e == nil {
+		refs := &RefsResult{Branch: branch}
+		refs.Head, _ = git.RevParse(ctx, ".", "HEAD")
+		refs.Upstream, _ = git.Upstream(ctx, ".")
+		res.Refs = refs
+	}
+	if err != nil {
+		kind := failure.KindOf(err)
+		res.Error = &ErrorResult{Kind: kind.String(), Code: int(kind), Message: err.Error()}
+	}
+	output.emit(Event{Type: "result", Result: &res})
+}
@@ -0,0 +1,95 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/git"
+	"github.com/charmbracelet/bubbles/progress"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ---------------- Output Log ----------------
+
+// outputLog shows the output of the running git commands: the phases git
+// reports with --progress as progress bars above a logView of the output, in
+// which the updates of a phase replace each other.
+type outputLog struct {
+	phases  []git.Progress // latest update of each phase of the running command
+	bar     progress.Model
+	log     logView
+	merging string // phase whose updates the last line shows
+}
+
+func newOutputLog() outputLog {
+	bar := theme.progressBar()
+	bar.Width = 30
+	return outputLog{bar: bar, log: newLogView()}
+}
+
+// capturing reports whether the log is taking typed text.
+func (o outputLog) capturing() bool { return o.log.capturing() }
+
+// update handles the messages the log reacts to; models pass on the keys and
+// window sizes they don't handle themselves.
+func (o outputLog) update(msg tea.Msg) (outputLog, tea.Cmd) {
+	switch msg := msg.(type) {
+	case stepMsg:
+		// a new command reports its own phases
+		o.phases = nil
+		o.merging = ""
+	case gitLineMsg:
+		o.add(string(msg))
+	default:
+		var cmd tea.Cmd
+		o.log, cmd = o.log.update(msg)
+		return o, cmd
+	}
+	return o, nil
+}
+
+// add appends a line of output, turning progress updates into bar updates.
+func (o *outputLog) add(line string) {
+	p, ok := git.ParseProgress(strings.TrimPrefix(line, "[stderr] "))
+	if !ok {
+		o.merging = ""
+		o.log.add(line)
+		return
+	}
+	i := 0
+	for i < len(o.phases) && o.phases[i].Phase != p.Phase {
+		i++
+	}
+	if i == len(o.phases) {
+		o.phases = append(o.phases, p)
+	} else {
+		o.phases[i] = p
+	}
+	if o.merging == p.Phase {
+		o.log.replaceLast(line)
+		return
+	}
+	o.merging = p.Phase
+	o.log.add(line)
+}
+
+func (o outputLog) View() string {
+	dim := theme.muted()
+
+	s := ""
+	width := 0
+	for _, p := range o.phases {
+		width = max(width, len(p.Phase))
+	}
+	for _, p := range o.phases {
+		s += fmt.Sprintf("%-*s %s %3d%% %s\n", width, p.Phase, o.bar.ViewAs(float64(p.Percent)/100), p.Percent,
+			dim.Render(fmt.Sprintf("%d/%d %s", p.Current, p.Total, p.Detail)))
+	}
+	if len(o.phases) > 0 {
+		s += "\n"
+	}
+	return s + o.log.View()
+}
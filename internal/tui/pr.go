@@ -0,0 +1,314 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/config"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/git"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/hosting"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// PROptions configures `gitmate pr`.
+type PROptions struct {
+	Base      string   // target branch; trunk when empty
+	Draft     *bool    // open as or convert to a draft; nil keeps an existing review's state
+	Reviewers []string // users to request reviews from
+	Labels    []string // labels to add
+	NoEdit    bool     // submit the generated title/body without opening the editor
+	NoSuggest bool     // don't prefill reviewers from CODEOWNERS and history
+}
+
+// ---------------- Target ----------------
+
+type prTarget struct {
+	provider hosting.Provider
+	head     string // branch name on the remote
+	base     string
+	existing *hosting.Review
+}
+
+// resolvePRTarget works out the hosting provider, branches and any open review.
+func resolvePRTarget(ctx context.Context, cfg config.Config, opts PROptions) (prTarget, error) {
+	upstream, err := git.Upstream(ctx, ".")
+	if err != nil {
+		return prTarget{}, fmt.Errorf("branch has no upstream; run `gitmate push` first")
+	}
+	remote, head := git.SplitRemoteRef(upstream)
+	provider, err := hosting.Detect(ctx, ".", remote, cfg)
+	if err != nil {
+		return prTarget{}, err
+	}
+	t := prTarget{provider: provider, head: head, base: opts.Base}
+	if t.base == "" {
+		t.base = cfg.Trunk
+	}
+	t.existing, err = provider.FindReview(ctx, head)
+	if err != nil {
+		return t, err
+	}
+	return t, nil
+}
+
+// ---------------- Messages ----------------
+
+type prDoneMsg struct{ review *hosting.Review }
+type prErrMsg error
+
+// submitPR creates or updates the review with its draft state, reviewers and labels.
+func submitPR(ctx context.Context, t prTarget, title, body string, draft bool, opts PROptions) tea.Cmd {
+	return func() tea.Msg {
+		in := hosting.ReviewInput{
+			Title:     title,
+			Body:      body,
+			Head:      t.head,
+			Base:      t.base,
+			Draft:     draft,
+			Reviewers: opts.Reviewers,
+			Labels:    opts.Labels,
+		}
+		var r *hosting.Review
+		var err error
+		if t.existing == nil {
+			r, err = t.provider.CreateReview(ctx, in)
+		} else {
+			r, err = t.provider.UpdateReview(ctx, t.existing.Number, in)
+		}
+		if err != nil {
+			return prErrMsg(err)
+		}
+		return prDoneMsg{review: r}
+	}
+}
+
+// ---------------- PR Model ----------------
+
+type prModel struct {
+	ctx       context.Context
+	target    prTarget
+	opts      PROptions
+	title     textinput.Model
+	body      textarea.Model
+	spinner   spinner.Model
+	suggested []string // reviewers prefilled from suggestReviewers
+	draft     bool
+	submitted bool
+	done      bool
+	err       error
+	review    *hosting.Review
+}
+
+func newPRModel(ctx context.Context, t prTarget, title, body string, opts PROptions) prModel {
+	ti := textinput.New()
+	ti.SetValue(title)
+	ti.CharLimit = 256
+	ti.Width = 72
+	ti.Focus()
+
+	ta := textarea.New()
+	ta.SetValue(body)
+	ta.SetWidth(80)
+	ta.SetHeight(16)
+	ta.ShowLineNumbers = false
+
+	// without --draft an existing review keeps its state and a new one is ready
+	draft := t.existing != nil && t.existing.Draft
+	if opts.Draft != nil {
+		draft = *opts.Draft
+	}
+
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	return prModel{ctx: ctx, target: t, opts: opts, title: ti, body: ta, spinner: s, draft: draft, submitted: opts.NoEdit}
+}
+
+func (m prModel) Init() tea.Cmd {
+	if m.submitted {
+		return tea.Batch(m.spinner.Tick, m.submitCmd())
+	}
+	return textinput.Blink
+}
+
+func (m prModel) submitCmd() tea.Cmd {
+	return submitPR(m.ctx, m.target, strings.TrimSpace(m.title.Value()), m.body.Value(), m.draft, m.opts)
+}
+
+func (m prModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.submitted {
+			if key.Matches(msg, keys.Quit, interrupt) {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+		switch {
+		case key.Matches(msg, keys.Cancel, interrupt):
+			m.done = true
+			return m, tea.Quit
+		case key.Matches(msg, keys.PRField):
+			if m.title.Focused() {
+				m.title.Blur()
+				return m, m.body.Focus()
+			}
+			m.body.Blur()
+			return m, m.title.Focus()
+		case key.Matches(msg, keys.PRDraft):
+			m.draft = !m.draft
+			return m, nil
+		case key.Matches(msg, keys.PRReviewers):
+			// toggle the suggested reviewers; reviewers given with -r are never touched
+			if len(m.suggested) > 0 {
+				if len(m.opts.Reviewers) > 0 {
+					m.opts.Reviewers = nil
+				} else {
+					m.opts.Reviewers = m.suggested
+				}
+			}
+			return m, nil
+		case key.Matches(msg, keys.PRSubmit):
+			if strings.TrimSpace(m.title.Value()) == "" {
+				m.err = fmt.Errorf("title cannot be empty")
+				return m, nil
+			}
+			m.err = nil
+			m.submitted = true
+			return m, tea.Batch(m.spinner.Tick, m.submitCmd())
+		}
+	case spinner.TickMsg:
+		if m.submitted && !m.done {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+	case prDoneMsg:
+		m.review = msg.review
+		m.done = true
+		return m, tea.Quit
+	case prErrMsg:
+		m.err = msg
+		m.done = true
+		return m, tea.Quit
+	}
+
+	var cmd tea.Cmd
+	if m.title.Focused() {
+		m.title, cmd = m.title.Update(msg)
+	} else {
+		m.body, cmd = m.body.Update(msg)
+	}
+	return m, cmd
+}
+
+func (m prModel) View() string {
+	noun := m.target.provider.ReviewNoun()
+	action := "Creating " + noun
+	if m.target.existing != nil {
+		action = fmt.Sprintf("Updating %s #%d", noun, m.target.existing.Number)
+	}
+	s := fmt.Sprintf("GitMate: %s %s → %s\n\n", action, m.target.head, m.target.base)
+
+	switch {
+	case m.done && m.err != nil:
+		return s + "Error: " + m.err.Error() + "\n"
+	case m.done && m.review != nil:
+		return s + fmt.Sprintf("✅ %s #%d: %s\n", m.target.provider.Name(), m.review.Number, m.review.URL)
+	case m.done:
+		return s + "Cancelled.\n"
+	case m.submitted:
+		return s + m.spinner.View() + " Talking to " + m.target.provider.Name() + "...\n"
+	}
+
+	draft := "no"
+	if m.draft {
+		draft = "yes"
+	}
+	s += "Title:\n" + m.title.View() + "\n\n"
+	s += "Body:\n" + m.body.View() + "\n\n"
+	s += fmt.Sprintf("Draft: %s", draft)
+	if len(m.opts.Reviewers) > 0 {
+		s += " · Reviewers: " + strings.Join(m.opts.Reviewers, ", ")
+		if len(m.suggested) > 0 {
+			s += " (suggested)"
+		}
+	}
+	if len(m.opts.Labels) > 0 {
+		s += " · Labels: " + strings.Join(m.opts.Labels, ", ")
+	}
+	s += "\n"
+	if m.err != nil {
+		s += "Error: " + m.err.Error() + "\n"
+	}
+	// the fields take typed text, so the keys are always listed rather than behind ?
+	bindings := []key.Binding{keys.PRField, keys.PRDraft}
+	if len(m.suggested) > 0 {
+		bindings = append(bindings, keys.PRReviewers)
+	}
+	s += "\n" + shortHelp(append(bindings, keys.PRSubmit, keys.Cancel)...)
+	return s
+}
+
+// ---------------- Public Entry ----------------
+
+func RunPRTUI(ctx context.Context, opts PROptions) error {
+	cfg, err := config.Load(ctx, ".")
+	if err != nil {
+		return err
+	}
+	t, err := resolvePRTarget(ctx, cfg, opts)
+	if err != nil {
+		return err
+	}
+
+	var title, body string
+	if t.existing != nil {
+		// keep what reviewers already see; draft state is toggled in the form
+		title, body = t.existing.Title, t.existing.Body
+	} else {
+		d, err := buildPRDescription(ctx, ".", cfg)
+		if err != nil {
+			return err
+		}
+		title, body = d.Title, d.Body
+	}
+
+	var suggested []string
+	if len(opts.Reviewers) == 0 && !opts.NoSuggest && t.existing == nil {
+		// suggestions are a convenience; a failure here shouldn't block the PR
+		if ranked, _, err := suggestReviewers(ctx, ".", git.TrunkRef(ctx, ".", t.base)); err == nil {
+			suggested = suggestedHandles(ranked, 2)
+			opts.Reviewers = suggested
+		}
+	}
+
+	// without a terminal there is no form to edit; submit what was generated
+	opts.NoEdit = opts.NoEdit || mode.Headless
+	m := newPRModel(ctx, t, title, body, opts)
+	m.suggested = suggested
+	final, err := runProgram(ctx, m, nil)
+	if err != nil {
+		return err
+	}
+	m, ok := final.(prModel)
+	if !ok {
+		return nil
+	}
+	if m.err != nil {
+		return finished(remoteFailure(m.err))
+	}
+	if mode.Headless && m.review != nil {
+		output.data(map[string]any{"number": m.review.Number, "url": m.review.URL, "draft": m.draft})
+		say("%s #%d: %s\n", m.target.provider.Name(), m.review.Number, m.review.URL)
+	}
+	return nil
+}
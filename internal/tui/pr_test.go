@@ -0,0 +1,36 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package tui
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/hosting"
+)
+
+func TestPRDraft(t *testing.T) {
+	yes, no := true, false
+	tests := []struct {
+		name     string
+		existing *hosting.Review // nil for a new pull request
+		flag     *bool           // --draft; nil when not given
+		want     bool
+	}{
+		{"new", nil, nil, false},
+		{"new draft", nil, &yes, true},
+		{"keeps draft", &hosting.Review{Draft: true}, nil, true},
+		{"keeps ready", &hosting.Review{}, nil, false},
+		{"--draft=false marks ready", &hosting.Review{Draft: true}, &no, false},
+		{"--draft converts", &hosting.Review{}, &yes, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newPRModel(context.Background(), prTarget{existing: tt.existing}, "Add login", "", PROptions{Draft: tt.flag})
+			if m.draft != tt.want {
+				t.Errorf("draft = %v, want %v", m.draft, tt.want)
+			}
+		})
+	}
+}
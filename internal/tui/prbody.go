@@ -0,0 +1,283 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package tui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/config"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/git"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/tracker"
+	"github.com/atotto/clipboard"
+)
+
+// PRBodyOptions configures where `gitmate pr-body` writes the description.
+type PRBodyOptions struct {
+	Clipboard bool   // copy to the system clipboard
+	OutFile   string // write to this file
+}
+
+// ---------------- Parsing ----------------
+
+var (
+	conventionalRe = regexp.MustCompile(`^(\w+)(?:\(([^)]*)\))?(!)?:\s*(.+)$`)
+	issueKeyRe     = regexp.MustCompile(`\b[A-Z][A-Z0-9]+-\d+\b|#\d+\b`)
+)
+
+// commitTypeTitles maps Conventional Commit types to section headings, in render order.
+var commitTypeTitles = []struct{ typ, title string }{
+	{"feat", "Features"},
+	{"fix", "Bug Fixes"},
+	{"perf", "Performance"},
+	{"refactor", "Refactoring"},
+	{"docs", "Documentation"},
+	{"test", "Tests"},
+	{"build", "Build & CI"},
+	{"ci", "Build & CI"},
+	{"chore", "Chores"},
+	{"", "Other Changes"},
+}
+
+type prCommit struct {
+	hash     string
+	typ      string // conventional type, empty when the subject does not follow the spec
+	scope    string
+	subject  string // description without the type prefix
+	breaking bool
+}
+
+// parseConventional splits a commit message into its Conventional Commit parts.
+func parseConventional(hash, msg string) prCommit {
+	subject := firstLine(msg)
+	c := prCommit{hash: hash, subject: subject}
+	if m := conventionalRe.FindStringSubmatch(subject); m != nil {
+		c.typ = strings.ToLower(m[1])
+		c.scope = m[2]
+		c.breaking = m[3] == "!"
+		c.subject = m[4]
+	}
+	if strings.Contains(msg, "BREAKING CHANGE:") || strings.Contains(msg, "BREAKING-CHANGE:") {
+		c.breaking = true
+	}
+	return c
+}
+
+// extractIssueKeys returns the unique issue references (PROJ-123, #45) in texts.
+func extractIssueKeys(texts ...string) []string {
+	seen := map[string]bool{}
+	var keys []string
+	for _, t := range texts {
+		for _, k := range issueKeyRe.FindAllString(t, -1) {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	return keys
+}
+
+// touchedAreas reduces changed paths to their top-level area; files under
+// internal/, pkg/, cmd/ or src/ keep one more segment so areas stay meaningful.
+func touchedAreas(paths []string) []string {
+	seen := map[string]bool{}
+	var areas []string
+	for _, p := range paths {
+		parts := strings.Split(p, "/")
+		area := "(root)"
+		if len(parts) > 1 {
+			area = parts[0]
+			switch parts[0] {
+			case "internal", "pkg", "cmd", "src":
+				if len(parts) > 2 {
+					area = path.Join(parts[0], parts[1])
+				}
+			}
+		}
+		if !seen[area] {
+			seen[area] = true
+			areas = append(areas, area)
+		}
+	}
+	sort.Strings(areas)
+	return areas
+}
+
+// ---------------- Building ----------------
+
+type prDescription struct {
+	Title  string
+	Body   string
+	Issues []string
+}
+
+// buildPRDescription drafts a pull request title and body from the commits on the
+// current branch since it diverged from trunk.
+func buildPRDescription(ctx context.Context, dir string, cfg config.Config) (prDescription, error) {
+	branch, err := git.CurrentBranch(ctx, dir)
+	if err != nil {
+		return prDescription{}, err
+	}
+	base, err := git.MergeBase(ctx, dir, git.TrunkRef(ctx, dir, cfg.Trunk), "HEAD")
+	if err != nil {
+		return prDescription{}, err
+	}
+
+	out, err := git.RunCombined(ctx, dir, "log", "--reverse", "--format=%H%x1f%B%x00", base+"..HEAD")
+	if err != nil {
+		return prDescription{}, err
+	}
+	var commits []prCommit
+	texts := []string{branch, tracker.LinkedKey(ctx, dir, branch)}
+	for _, rec := range strings.Split(out, "\x00") {
+		hash, msg, ok := strings.Cut(strings.TrimSpace(rec), "\x1f")
+		if !ok {
+			continue
+		}
+		commits = append(commits, parseConventional(hash, msg))
+		texts = append(texts, msg)
+	}
+	if len(commits) == 0 {
+		return prDescription{}, fmt.Errorf("no commits on %s since %s", branch, cfg.Trunk)
+	}
+
+	paths, err := git.DiffNames(ctx, dir, base, "HEAD")
+	if err != nil {
+		return prDescription{}, err
+	}
+
+	d := prDescription{Issues: extractIssueKeys(texts...)}
+	d.Title = prTitle(branch, commits)
+	d.Body = renderPRBody(commits, d.Issues, touchedAreas(paths), cfg.PR.Checklist)
+	return d, nil
+}
+
+// prTitle uses the only commit's subject, or otherwise a title derived from the branch name.
+func prTitle(branch string, commits []prCommit) string {
+	if len(commits) == 1 {
+		return firstLine(commits[0].subject)
+	}
+	name := branch
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	// keep a leading issue key intact: PROJ-12-login-form → "PROJ-12: Login form"
+	prefix := ""
+	if loc := issueKeyRe.FindStringIndex(name); loc != nil && loc[0] == 0 {
+		prefix = name[:loc[1]] + ": "
+		name = strings.TrimLeft(name[loc[1]:], "-_")
+	}
+	name = strings.NewReplacer("-", " ", "_", " ").Replace(name)
+	if name == "" {
+		return branch
+	}
+	return prefix + strings.ToUpper(name[:1]) + name[1:]
+}
+
+// renderPRBody renders the Markdown description.
+func renderPRBody(commits []prCommit, issues, areas, checklist []string) string {
+	var b strings.Builder
+	b.WriteString("## Summary\n\n")
+
+	groups := map[string][]prCommit{}
+	var breaking []prCommit
+	for _, c := range commits {
+		groups[sectionFor(c.typ)] = append(groups[sectionFor(c.typ)], c)
+		if c.breaking {
+			breaking = append(breaking, c)
+		}
+	}
+	rendered := map[string]bool{}
+	for _, t := range commitTypeTitles {
+		if rendered[t.title] || len(groups[t.title]) == 0 {
+			continue
+		}
+		rendered[t.title] = true
+		fmt.Fprintf(&b, "### %s\n\n", t.title)
+		for _, c := range groups[t.title] {
+			line := c.subject
+			if c.scope != "" {
+				line = fmt.Sprintf("**%s:** %s", c.scope, c.subject)
+			}
+			fmt.Fprintf(&b, "- %s (%s)\n", line, shortHash(c.hash))
+		}
+		b.WriteString("\n")
+	}
+
+	if len(breaking) > 0 {
+		b.WriteString("## ⚠️ Breaking Changes\n\n")
+		for _, c := range breaking {
+			fmt.Fprintf(&b, "- %s (%s)\n", c.subject, shortHash(c.hash))
+		}
+		b.WriteString("\n")
+	}
+	if len(issues) > 0 {
+		b.WriteString("## Linked Issues\n\n")
+		for _, k := range issues {
+			fmt.Fprintf(&b, "- %s\n", k)
+		}
+		b.WriteString("\n")
+	}
+	if len(areas) > 0 {
+		b.WriteString("## Touched Areas\n\n")
+		for _, a := range areas {
+			fmt.Fprintf(&b, "- `%s`\n", a)
+		}
+		b.WriteString("\n")
+	}
+	if len(checklist) > 0 {
+		b.WriteString("## Checklist\n\n")
+		for _, item := range checklist {
+			fmt.Fprintf(&b, "- [ ] %s\n", item)
+		}
+	}
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// sectionFor returns the heading a commit type is listed under.
+func sectionFor(typ string) string {
+	for _, t := range commitTypeTitles {
+		if t.typ == typ && typ != "" {
+			return t.title
+		}
+	}
+	return "Other Changes"
+}
+
+// ---------------- Public Entry ----------------
+
+// RunPRBody prints (or copies/writes) a generated pull request description.
+func RunPRBody(ctx context.Context, opts PRBodyOptions) error {
+	cfg, err := config.Load(ctx, ".")
+	if err != nil {
+		return err
+	}
+	d, err := buildPRDescription(ctx, ".", cfg)
+	if err != nil {
+		return err
+	}
+	text := "# " + d.Title + "\n\n" + d.Body
+
+	switch {
+	case opts.OutFile != "":
+		if err := os.WriteFile(opts.OutFile, []byte(text), 0o644); err != nil {
+			return err
+		}
+		fmt.Printf("✅ PR description written to %s\n", opts.OutFile)
+	case opts.Clipboard:
+		if err := clipboard.WriteAll(text); err != nil {
+			return fmt.Errorf("copying to clipboard: %w", err)
+		}
+		fmt.Println("✅ PR description copied to clipboard.")
+	default:
+		fmt.Print(text)
+	}
+	return nil
+}
@@ -0,0 +1,429 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package tui
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/git"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/workflow"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// PruneOptions configures `gitmate prune-branches`.
+type PruneOptions struct {
+	StaleDays int  // branches without commits for this many days are offered (0 disables)
+	Remote    bool // also delete the branch on its remote
+}
+
+// ---------------- Candidates ----------------
+
+type pruneCandidate struct {
+	branch  git.Branch
+	reasons []string
+	warning string // why the branch may be missing a reason, e.g. a failed squash check
+}
+
+// findPruneCandidates classifies local branches as merged, squash-merged, gone or stale.
+// The current branch and trunk are never offered. Branches whose squash check
+// failed are offered with the error as a warning, so they aren't silently kept.
+func findPruneCandidates(ctx context.Context, dir, trunk string, staleDays int) ([]pruneCandidate, error) {
+	branches, err := git.ListBranches(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+	current, _ := git.CurrentBranch(ctx, dir)
+	trunkRef := git.TrunkRef(ctx, dir, trunk)
+	cutoff := time.Now().AddDate(0, 0, -staleDays)
+
+	var res []pruneCandidate
+	for _, b := range branches {
+		if b.Name == current || b.Name == trunk {
+			continue
+		}
+		var reasons []string
+		var warning string
+		merged, err := git.IsAncestor(ctx, dir, b.Hash, trunkRef)
+		if err != nil {
+			return nil, err
+		}
+		if merged {
+			reasons = append(reasons, "merged")
+		} else if squashed, err := git.IsSquashMerged(ctx, dir, b.Name, trunkRef); err != nil {
+			warning = "could not check for a squash merge: " + err.Error()
+		} else if squashed {
+			reasons = append(reasons, "squash-merged")
+		}
+		if b.UpstreamGone {
+			reasons = append(reasons, "upstream gone")
+		}
+		if staleDays > 0 && b.LastCommit.Before(cutoff) {
+			reasons = append(reasons, fmt.Sprintf("stale %s", humanizeAge(b.LastCommit)))
+		}
+		if len(reasons) > 0 || warning != "" {
+			res = append(res, pruneCandidate{branch: b, reasons: reasons, warning: warning})
+		}
+	}
+	return res, nil
+}
+
+// describe lists why c is offered.
+func (c pruneCandidate) describe() string {
+	if len(c.reasons) == 0 {
+		return "unknown"
+	}
+	return strings.Join(c.reasons, ", ")
+}
+
+// ---------------- Select Model ----------------
+
+type pruneSelectModel struct {
+	candidates []pruneCandidate
+	selected   map[int]bool
+	cursor     int
+	help       helpOverlay
+	done       bool
+	confirmed  bool
+}
+
+func newPruneSelectModel(candidates []pruneCandidate) pruneSelectModel {
+	selected := map[int]bool{}
+	for i, c := range candidates {
+		// only preselect branches whose work is provably on trunk
+		for _, r := range c.reasons {
+			if r == "merged" || r == "squash-merged" {
+				selected[i] = true
+			}
+		}
+	}
+	return pruneSelectModel{candidates: candidates, selected: selected}
+}
+
+func (m pruneSelectModel) Init() tea.Cmd { return nil }
+
+func (m pruneSelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		var handled bool
+		if m.help, handled = m.help.update(msg); handled {
+			return m, nil
+		}
+		switch {
+		case key.Matches(msg, keys.Up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case key.Matches(msg, keys.Down):
+			if m.cursor < len(m.candidates)-1 {
+				m.cursor++
+			}
+		case key.Matches(msg, keys.Toggle):
+			m.selected[m.cursor] = !m.selected[m.cursor]
+		case key.Matches(msg, keys.All):
+			all := len(m.chosen()) < len(m.candidates)
+			for i := range m.candidates {
+				m.selected[i] = all
+			}
+		case key.Matches(msg, keys.Select):
+			m.done = true
+			m.confirmed = len(m.chosen()) > 0
+			return m, tea.Quit
+		case key.Matches(msg, keys.Quit, interrupt):
+			m.done = true
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+// chosen returns the selected candidates in display order.
+func (m pruneSelectModel) chosen() []pruneCandidate {
+	var res []pruneCandidate
+	for i, c := range m.candidates {
+		if m.selected[i] {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+func (m pruneSelectModel) View() string {
+	switch {
+	case m.done:
+		return ""
+	case m.help.open:
+		return m.help.View([]key.Binding{keys.Up, keys.Down, keys.Toggle, keys.All}, []key.Binding{m.deleteKey(), keys.Quit, keys.Help})
+	}
+	dim := theme.muted()
+	active := theme.accent().Bold(true)
+
+	s := "GitMate: Select branches to delete\n\n"
+	for i, c := range m.candidates {
+		box := "[ ]"
+		if m.selected[i] {
+			box = "[x]"
+		}
+		line := fmt.Sprintf("%s %s  (%s)", box, c.branch.Name, c.describe())
+		if i == m.cursor {
+			line = active.Render("> " + line)
+		} else {
+			line = "  " + line
+		}
+		s += line + "\n"
+		s += dim.Render(fmt.Sprintf("      %s  %s · %s · %s",
+			shortHash(c.branch.Hash), humanizeAge(c.branch.LastCommit), c.branch.Author, c.branch.Subject)) + "\n"
+		if c.warning != "" {
+			s += theme.warn().Render("      ⚠ "+c.warning) + "\n"
+		}
+	}
+	s += fmt.Sprintf("\n%d selected ", len(m.chosen())) + shortHelp(keys.Toggle, keys.All, m.deleteKey(), keys.Quit, keys.Help) + "\n"
+	return s
+}
+
+// deleteKey is the select binding, which deletes the chosen branches here.
+func (m pruneSelectModel) deleteKey() key.Binding {
+	b := keys.Select
+	b.SetHelp(b.Help().Key, "delete")
+	return b
+}
+
+// ---------------- Prune Model ----------------
+
+type pruneModel struct {
+	runModel
+	count    int
+	snapshot string
+}
+
+func newPruneModel(ctx context.Context, count int, snapshot string) pruneModel {
+	m := pruneModel{runModel: newRunModel(ctx, fmt.Sprintf("Deleting %d branches", count), workflow.Workflow{}, nil), count: count, snapshot: snapshot}
+	m.quitOnDone = true
+	return m
+}
+
+func (m pruneModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.runModel, cmd = m.update(msg)
+	return m, cmd
+}
+
+func (m pruneModel) View() string {
+	if m.help.open {
+		return m.helpView()
+	}
+	s := m.header.View() + "\n"
+	s += m.status("Running git branch -D...", "Branches deleted.") + "\n"
+	s += m.log.View()
+	if m.done && m.snapshot != "" {
+		s += fmt.Sprintf("\nBranch tips saved to %s\nRestore them with `gitmate prune-branches --undo`.\n", m.snapshot)
+	}
+	return s + m.footer()
+}
+
+// ---------------- Snapshots ----------------
+
+// pruneSnapshotPath returns the file where deleted branch tips are recorded.
+func pruneSnapshotPath(ctx context.Context, dir string) (string, error) {
+	gitDir, err := git.GitDir(ctx, dir)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(gitDir, "gitmate", "pruned-branches"), nil
+}
+
+// saveBranchSnapshot appends "<run id>\t<branch>\t<hash>" lines for the given
+// candidates so that a later --undo can recreate them. The run id tells prunes
+// apart even when they happen in the same second.
+func saveBranchSnapshot(ctx context.Context, dir string, candidates []pruneCandidate) (string, error) {
+	path, err := pruneSnapshotPath(ctx, dir)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	run := fmt.Sprintf("%d.%d", time.Now().UnixNano(), os.Getpid())
+	for _, c := range candidates {
+		if _, err := fmt.Fprintf(f, "%s\t%s\t%s\n", run, c.branch.Name, c.branch.Hash); err != nil {
+			return "", err
+		}
+	}
+	return path, nil
+}
+
+type branchTip struct {
+	name string
+	hash string
+}
+
+// lastBranchSnapshot returns the tips recorded by the most recent prune: those
+// with the run id of the last line, as runs are only ever appended.
+func lastBranchSnapshot(ctx context.Context, dir string) ([]branchTip, error) {
+	path, err := pruneSnapshotPath(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var latest string
+	var tips []branchTip
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		parts := strings.Split(scanner.Text(), "\t")
+		if len(parts) != 3 {
+			continue
+		}
+		if parts[0] != latest {
+			latest = parts[0]
+			tips = nil
+		}
+		tips = append(tips, branchTip{name: parts[1], hash: parts[2]})
+	}
+	return tips, scanner.Err()
+}
+
+// ---------------- Orchestration ----------------
+
+// runPrune deletes each chosen branch in turn, and its remote copy when requested.
+// The snapshot taken beforehand is the undo, so the steps have no rollback.
+func runPrune(ctx context.Context, p sender, candidates []pruneCandidate, remote bool) {
+	w := workflow.Workflow{Name: "prune"}
+	for _, c := range candidates {
+		w.Steps = append(w.Steps, workflow.Step{
+			Name: "Delete " + c.branch.Name,
+			Git:  []string{"branch", "-D", c.branch.Name},
+		})
+		if remote && c.branch.Upstream != "" && !c.branch.UpstreamGone {
+			r, name := git.SplitRemoteRef(c.branch.Upstream)
+			w.Steps = append(w.Steps, workflow.Step{
+				Name: "Delete " + c.branch.Upstream,
+				Git:  []string{"push", r, "--delete", name},
+			})
+		}
+	}
+	runWorkflow(ctx, p, w, nil)
+}
+
+// ---------------- Public Entry ----------------
+
+func RunPruneTUI(ctx context.Context, opts PruneOptions) error {
+	return runApp(ctx, func(ctx context.Context) error { return pruneFlow(ctx, opts) })
+}
+
+// pruneFlow picks the branches to delete and deletes them.
+func pruneFlow(ctx context.Context, opts PruneOptions) error {
+	trunk := git.DefaultBranch(ctx, ".")
+	candidates, err := findPruneCandidates(ctx, ".", trunk, opts.StaleDays)
+	if err != nil {
+		return err
+	}
+	if len(candidates) == 0 {
+		say("No merged, gone or stale branches found. Nothing to prune.\n")
+		return nil
+	}
+
+	chosen, err := choosePruneCandidates(candidates)
+	if err != nil || len(chosen) == 0 {
+		return err
+	}
+
+	snapshot, err := saveBranchSnapshot(ctx, ".", chosen)
+	if err != nil {
+		return fmt.Errorf("saving branch snapshot: %w", err)
+	}
+
+	names := []string{}
+	for _, c := range chosen {
+		names = append(names, c.branch.Name)
+	}
+	output.data(map[string]any{"branches": names, "snapshot": snapshot})
+
+	final, err := runProgram(ctx, newPruneModel(ctx, len(chosen), snapshot), func(ctx context.Context, p sender) {
+		runPrune(ctx, p, chosen, opts.Remote)
+	})
+	if err != nil {
+		return err
+	}
+	if m, ok := final.(pruneModel); ok {
+		if mode.Headless && m.err == nil {
+			say("Branch tips saved to %s\n", snapshot)
+		}
+		return finished(m.err)
+	}
+	return nil
+}
+
+// choosePruneCandidates lets the user pick the branches to delete. --yes takes the
+// preselection: branches whose work is provably on trunk.
+func choosePruneCandidates(candidates []pruneCandidate) ([]pruneCandidate, error) {
+	preselected := newPruneSelectModel(candidates)
+	if mode.Yes {
+		chosen := preselected.chosen()
+		if len(chosen) == 0 {
+			say("No merged branches found. Stale and gone branches are only deleted when picked.\n")
+		}
+		return chosen, nil
+	}
+	if mode.Headless {
+		for _, c := range candidates {
+			say("%s (%s)\n", c.branch.Name, c.describe())
+			if c.warning != "" {
+				say("  [warn] %s\n", c.warning)
+			}
+		}
+		return nil, needsAnswer("deleting branches", "--yes")
+	}
+	final, err := show(preselected)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := final.(pruneSelectModel)
+	if !ok || !m.confirmed {
+		return nil, nil
+	}
+	return m.chosen(), nil
+}
+
+// UndoPrune recreates the branches deleted by the most recent prune.
+func UndoPrune(ctx context.Context) error {
+	tips, err := lastBranchSnapshot(ctx, ".")
+	if err != nil {
+		return err
+	}
+	if len(tips) == 0 {
+		fmt.Println("No pruned branches recorded. Nothing to undo.")
+		return nil
+	}
+	for _, t := range tips {
+		if git.RefExists(ctx, ".", "refs/heads/"+t.name) {
+			fmt.Printf("skipped %s: branch already exists\n", t.name)
+			continue
+		}
+		if _, err := git.RunCombined(ctx, ".", "branch", t.name, t.hash); err != nil {
+			return err
+		}
+		fmt.Printf("restored %s at %s\n", t.name, shortHash(t.hash))
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package tui
+
+import (
+	"context"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/git"
+)
+
+// pruneRepo makes a repository on main with a merged, a squash-merged, an
+// unmerged and an unrelated branch.
+func pruneRepo(t *testing.T) string {
+	t.Helper()
+	for _, k := range []string{"GIT_AUTHOR", "GIT_COMMITTER"} {
+		t.Setenv(k+"_NAME", "Ama")
+		t.Setenv(k+"_EMAIL", "ama@example.com")
+	}
+	dir := t.TempDir()
+	for _, script := range []string{
+		"git init -q -b main && git commit -q --allow-empty -m init",
+		"git switch -q -c merged && echo a > a && git add a && git commit -q -m a && git switch -q main && git merge -q merged",
+		"git switch -q -c squashed && echo b > b && git add b && git commit -q -m b1 && echo bb >> b && git commit -q -am b2",
+		"git switch -q main && git merge -q --squash squashed && git commit -q -m 'b (squashed)'",
+		"git switch -q -c open && echo c > c && git add c && git commit -q -m c && git switch -q main",
+		// no history in common with main, so the squash check fails
+		"git switch -q --orphan lone && git commit -q --allow-empty -m lone && git switch -q main",
+	} {
+		cmd := exec.Command("sh", "-c", script)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("%s: %v\n%s", script, err, out)
+		}
+	}
+	return dir
+}
+
+func TestFindPruneCandidates(t *testing.T) {
+	dir := pruneRepo(t)
+	candidates, err := findPruneCandidates(context.Background(), dir, "main", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]string{}
+	for _, c := range candidates {
+		got[c.branch.Name] = c.describe()
+		if c.warning != "" {
+			got[c.branch.Name] += " ⚠"
+		}
+	}
+	want := map[string]string{
+		"merged":   "merged",
+		"squashed": "squash-merged",
+		"lone":     "unknown ⚠",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("candidates = %v, want %v", got, want)
+	}
+
+	// only the provably merged branches are preselected
+	var chosen []string
+	for _, c := range newPruneSelectModel(candidates).chosen() {
+		chosen = append(chosen, c.branch.Name)
+	}
+	if strings.Join(chosen, " ") != "merged squashed" {
+		t.Errorf("preselected %v, want merged and squashed", chosen)
+	}
+}
+
+func TestBranchSnapshotRuns(t *testing.T) {
+	ctx := context.Background()
+	dir := pruneRepo(t)
+	candidate := func(name string) pruneCandidate {
+		hash, err := git.RevParse(ctx, dir, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pruneCandidate{branch: git.Branch{Name: name, Hash: hash}}
+	}
+
+	// two prunes within the same second are still told apart
+	for _, run := range [][]pruneCandidate{
+		{candidate("merged"), candidate("squashed")},
+		{candidate("open")},
+	} {
+		if _, err := saveBranchSnapshot(ctx, dir, run); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tips, err := lastBranchSnapshot(ctx, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tips) != 1 || tips[0].name != "open" || tips[0].hash != candidate("open").branch.Hash {
+		t.Errorf("last snapshot = %+v, want only open", tips)
+	}
+}
@@ -0,0 +1,283 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package tui
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/config"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/git"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/workflow"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// leaseKey is the branch config key holding the remote commit GitMate saw before it
+// rewrote local history; pushes are leased against it.
+const leaseKey = "gitmateLease"
+
+// recordLease remembers the current upstream commit of HEAD before a history
+// rewrite (sync/clean), unless one is already recorded from an earlier rewrite.
+func recordLease(ctx context.Context, dir string) {
+	branch, err := git.CurrentBranch(ctx, dir)
+	if err != nil || branch == "HEAD" {
+		return
+	}
+	if git.BranchConfig(ctx, dir, branch, leaseKey) != "" {
+		return
+	}
+	upstream, err := git.Upstream(ctx, dir)
+	if err != nil {
+		return
+	}
+	if sha, err := git.RevParse(ctx, dir, upstream); err == nil {
+		_ = git.SetBranchConfig(ctx, dir, branch, leaseKey, sha)
+	}
+}
+
+// leaseStep runs recordLease as a workflow step.
+var leaseStep = workflow.Step{
+	Name:    "Remember the remote tip",
+	Explain: "`gitmate push` leases against it, so it can't overwrite commits you haven't seen.",
+	Do: func(ctx context.Context, log func(string)) error {
+		recordLease(ctx, ".")
+		return nil
+	},
+}
+
+// ---------------- Plan ----------------
+
+type pushPlan struct {
+	branch       string   // local branch being pushed
+	remote       string   // remote to push to
+	remoteBranch string   // branch name on the remote
+	setUpstream  bool     // branch has no upstream yet
+	rewritten    bool     // local history no longer contains the remote tip
+	lease        string   // remote commit the force push is leased against
+	publish      []string // commits that will be published
+	discard      []string // remote commits a forced push will overwrite
+}
+
+// isProtected reports whether branch matches one of the protected globs.
+func isProtected(branch string, globs []string) bool {
+	for _, g := range globs {
+		if ok, _ := path.Match(g, branch); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// planPush works out how the current branch should be pushed and what it will publish.
+func planPush(ctx context.Context, dir string, cfg config.Config) (pushPlan, error) {
+	branch, err := git.CurrentBranch(ctx, dir)
+	if err != nil {
+		return pushPlan{}, err
+	}
+	if branch == "HEAD" {
+		return pushPlan{}, fmt.Errorf("HEAD is detached; check out a branch before pushing")
+	}
+	plan := pushPlan{branch: branch, remote: cfg.Push.Remote, remoteBranch: branch}
+
+	upstream, err := git.Upstream(ctx, dir)
+	if err != nil {
+		plan.setUpstream = true
+		// an existing remote branch of the same name still has to be respected
+		if candidate := plan.remote + "/" + branch; git.RefExists(ctx, dir, "refs/remotes/"+candidate) {
+			upstream = candidate
+		}
+	}
+	if upstream != "" {
+		plan.remote, plan.remoteBranch = git.SplitRemoteRef(upstream)
+	}
+	if isProtected(plan.branch, cfg.Push.Protected) || isProtected(plan.remoteBranch, cfg.Push.Protected) {
+		return plan, fmt.Errorf("refusing to push to protected branch %s", plan.remoteBranch)
+	}
+
+	base := upstream
+	if base == "" {
+		base = git.TrunkRef(ctx, dir, cfg.Trunk)
+	}
+	out, err := git.RunCombined(ctx, dir, "log", "--oneline", base+"..HEAD")
+	if err != nil {
+		return plan, err
+	}
+	if out != "" {
+		plan.publish = strings.Split(out, "\n")
+	}
+
+	if upstream != "" {
+		contained, err := git.IsAncestor(ctx, dir, upstream, "HEAD")
+		if err != nil {
+			return plan, err
+		}
+		plan.rewritten = !contained
+	}
+	if plan.rewritten {
+		plan.lease = git.BranchConfig(ctx, dir, branch, leaseKey)
+		if plan.lease == "" {
+			if plan.lease, err = git.RevParse(ctx, dir, upstream); err != nil {
+				return plan, err
+			}
+		}
+		out, err := git.RunCombined(ctx, dir, "log", "--oneline", "HEAD.."+upstream)
+		if err != nil {
+			return plan, err
+		}
+		if out != "" {
+			plan.discard = strings.Split(out, "\n")
+		}
+	}
+	return plan, nil
+}
+
+// args returns the git push arguments for the plan.
+func (p pushPlan) args() []string {
+	var args []string
+	if p.setUpstream {
+		args = append(args, "-u")
+	}
+	if p.rewritten {
+		args = append(args, fmt.Sprintf("--force-with-lease=refs/heads/%s:%s", p.remoteBranch, p.lease))
+	}
+	return append(args, p.remote, p.branch+":refs/heads/"+p.remoteBranch)
+}
+
+// ---------------- Summary ----------------
+
+// pushSummary describes what the push will publish and overwrite.
+func pushSummary(p pushPlan) string {
+	warn := theme.bad().Bold(true)
+
+	s := fmt.Sprintf("GitMate: Push %s → %s/%s\n\n", p.branch, p.remote, p.remoteBranch)
+	if p.setUpstream {
+		s += fmt.Sprintf("Upstream will be set to %s/%s.\n\n", p.remote, p.remoteBranch)
+	}
+	if len(p.publish) > 0 {
+		s += fmt.Sprintf("Commits to publish (%d):\n", len(p.publish))
+		for _, c := range p.publish {
+			s += " + " + c + "\n"
+		}
+		s += "\n"
+	}
+	if p.rewritten {
+		s += warn.Render("History was rewritten; pushing with --force-with-lease.") + "\n"
+		s += fmt.Sprintf("The push only succeeds if the remote is still at %s.\n", shortHash(p.lease))
+		if len(p.discard) > 0 {
+			s += "Remote commits that will be replaced:\n"
+			for _, c := range p.discard {
+				s += " - " + c + "\n"
+			}
+		}
+		s += "\n"
+	}
+	return s
+}
+
+// ---------------- Push Model ----------------
+
+type pushModel struct {
+	runModel
+	plan pushPlan
+}
+
+func newPushModel(ctx context.Context, plan pushPlan) pushModel {
+	m := pushModel{runModel: newRunModel(ctx, "Pushing "+plan.branch, pushWorkflow(plan), nil), plan: plan}
+	m.quitOnDone = true
+	return m
+}
+
+func (m pushModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.runModel, cmd = m.update(msg)
+	return m, cmd
+}
+
+func (m pushModel) View() string {
+	if m.help.open {
+		return m.helpView()
+	}
+	s := m.header.View() + "\n"
+	s += m.status("Running git push...", fmt.Sprintf("Pushed to %s/%s.", m.plan.remote, m.plan.remoteBranch))
+	if m.err != nil && m.plan.rewritten {
+		s += "The remote moved since GitMate last saw it. Run `gitmate sync` to pick up the new commits.\n"
+	}
+	s += "\n" + m.log.View()
+	return s + m.footer()
+}
+
+// ---------------- Orchestration ----------------
+
+// pushWorkflow pushes the plan and forgets the lease it no longer needs.
+func pushWorkflow(plan pushPlan) workflow.Workflow {
+	return workflow.Workflow{Name: "push", Steps: []workflow.Step{
+		{
+			Name: "Push " + plan.branch,
+			Git:  append([]string{"push"}, plan.args()...),
+		},
+		{
+			Name: "Forget the remote tip",
+			Do: func(ctx context.Context, log func(string)) error {
+				_ = git.UnsetBranchConfig(ctx, ".", plan.branch, leaseKey)
+				return nil
+			},
+		},
+	}}
+}
+
+func runPush(ctx context.Context, p sender, plan pushPlan) {
+	runWorkflow(ctx, p, pushWorkflow(plan), nil)
+}
+
+// ---------------- Public Entry ----------------
+
+func RunPushTUI(ctx context.Context) error {
+	return runApp(ctx, pushFlow)
+}
+
+// pushFlow plans the push and runs it once confirmed.
+func pushFlow(ctx context.Context) error {
+	cfg, err := config.Load(ctx, ".")
+	if err != nil {
+		return err
+	}
+	plan, err := planPush(ctx, ".", cfg)
+	if err != nil {
+		return err
+	}
+	if !plan.setUpstream && !plan.rewritten && len(plan.publish) == 0 {
+		say("Everything up to date. Nothing to push.\n")
+		return nil
+	}
+
+	if !mode.Yes {
+		if mode.Headless {
+			say("%s", pushSummary(plan))
+			return needsAnswer("pushing", "--yes")
+		}
+		yes, err := confirm(pushSummary(plan), "Push now?")
+		if err != nil || !yes {
+			return err
+		}
+	}
+
+	output.data(map[string]any{
+		"remote":         plan.remote,
+		"remoteBranch":   plan.remoteBranch,
+		"setUpstream":    plan.setUpstream,
+		"forceWithLease": plan.rewritten,
+		"publish":        plan.publish,
+		"discard":        plan.discard,
+	})
+	final, err := runProgram(ctx, newPushModel(ctx, plan), func(ctx context.Context, p sender) { runPush(ctx, p, plan) })
+	if err != nil {
+		return err
+	}
+	if m, ok := final.(pushModel); ok {
+		return finished(m.err)
+	}
+	return nil
+}
@@ -0,0 +1,414 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package tui
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/config"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/failure"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/git"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ---------------- Checks ----------------
+
+type readyCheck struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail,omitempty"`
+
+	fixKey   key.Binding                 // key that triggers fix in the TUI
+	fixLabel string                      // short description of what fix does
+	fix      func(context.Context) error // optional one-key fix for a failing check
+}
+
+type readyReport struct {
+	Branch string       `json:"branch"`
+	Trunk  string       `json:"trunk"`
+	Ready  bool         `json:"ready"`
+	Checks []readyCheck `json:"checks"`
+}
+
+var conflictMarkerRe = regexp.MustCompile(`^(<{7} |={7}$|>{7} )`)
+
+// evaluateReady runs the readiness checklist for the current branch against trunk.
+func evaluateReady(ctx context.Context, dir string, cfg config.Config) (readyReport, error) {
+	branch, err := git.CurrentBranch(ctx, dir)
+	if err != nil {
+		return readyReport{}, err
+	}
+	trunkRef := git.TrunkRef(ctx, dir, cfg.Trunk)
+	base, err := git.MergeBase(ctx, dir, trunkRef, "HEAD")
+	if err != nil {
+		return readyReport{}, err
+	}
+	rng := base + "..HEAD"
+	r := readyReport{Branch: branch, Trunk: trunkRef}
+
+	// 1. up to date with trunk
+	_, behind, err := git.AheadBehind(ctx, dir, trunkRef, "HEAD")
+	if err != nil {
+		return r, err
+	}
+	up := readyCheck{Name: "Up to date with " + trunkRef, Passed: behind == 0,
+		fixKey: keys.FixSync, fixLabel: "sync", fix: RunSyncTUI}
+	if behind > 0 {
+		up.Detail = fmt.Sprintf("%d commits behind", behind)
+	}
+	r.Checks = append(r.Checks, up)
+
+	// 2. noisy commits
+	oneline, err := git.RunCombined(ctx, dir, "log", "--oneline", rng)
+	if err != nil {
+		return r, err
+	}
+	noisy := findNoisyCommits(oneline)
+	r.Checks = append(r.Checks, readyCheck{Name: "No noisy commits", Passed: len(noisy) == 0,
+		Detail: strings.Join(noisy, "; "), fixKey: keys.FixClean, fixLabel: "clean", fix: RunCleanTUI})
+
+	// 3. commit message lint
+	msgs, err := commitMessages(ctx, dir, rng)
+	if err != nil {
+		return r, err
+	}
+	var lint []string
+	for _, msg := range msgs {
+		for _, problem := range lintCommitMessage(msg) {
+			lint = append(lint, fmt.Sprintf("%q: %s", firstLine(msg), problem))
+		}
+	}
+	r.Checks = append(r.Checks, readyCheck{Name: "Commit messages lint-clean", Passed: len(lint) == 0,
+		Detail: strings.Join(lint, "; ")})
+
+	// 4. merge commits
+	merges, err := git.RunCombined(ctx, dir, "rev-list", "--merges", "--count", rng)
+	if err != nil {
+		return r, err
+	}
+	mc := readyCheck{Name: "No merge commits", Passed: merges == "0",
+		fixKey: keys.FixSync, fixLabel: "sync (rebase drops merges)", fix: RunSyncTUI}
+	if merges != "0" {
+		mc.Detail = merges + " merge commits"
+	}
+	r.Checks = append(r.Checks, mc)
+
+	// 5 & 6. conflict markers and debug statements in added lines
+	markers, debug, err := scanAddedLines(ctx, dir, base, cfg.Ready.DebugPatterns)
+	if err != nil {
+		return r, err
+	}
+	r.Checks = append(r.Checks,
+		readyCheck{Name: "No conflict markers", Passed: len(markers) == 0, Detail: strings.Join(markers, "; ")},
+		readyCheck{Name: "No debug statements", Passed: len(debug) == 0, Detail: strings.Join(debug, "; ")},
+	)
+
+	// 7. diff size
+	size, err := diffSize(ctx, dir, base)
+	if err != nil {
+		return r, err
+	}
+	r.Checks = append(r.Checks, readyCheck{
+		Name:   fmt.Sprintf("Diff under %d lines", cfg.Ready.MaxDiffLines),
+		Passed: cfg.Ready.MaxDiffLines <= 0 || size <= cfg.Ready.MaxDiffLines,
+		Detail: fmt.Sprintf("%d lines changed", size),
+	})
+
+	// 8. branch name policy
+	bn := readyCheck{Name: "Branch name follows policy", Passed: true}
+	if cfg.Branch.Pattern != "" {
+		re, err := regexp.Compile(cfg.Branch.Pattern)
+		if err != nil {
+			return r, fmt.Errorf("invalid branch.pattern: %w", err)
+		}
+		if !re.MatchString(branch) {
+			bn.Passed = false
+			bn.Detail = fmt.Sprintf("%q does not match %s", branch, cfg.Branch.Pattern)
+			if renamed := "feature/" + sanitizeBranchName(branch); re.MatchString(renamed) {
+				bn.fixKey, bn.fixLabel = keys.FixRename, "rename to "+renamed
+				bn.fix = func(ctx context.Context) error {
+					_, err := git.RunCombined(ctx, dir, "branch", "-m", renamed)
+					return err
+				}
+			}
+		}
+	}
+	r.Checks = append(r.Checks, bn)
+
+	// 9. pushed to upstream
+	r.Checks = append(r.Checks, pushedCheck(ctx, dir))
+
+	r.Ready = true
+	for _, c := range r.Checks {
+		r.Ready = r.Ready && c.Passed
+	}
+	return r, nil
+}
+
+// pushedCheck verifies HEAD has an upstream and nothing left to publish.
+func pushedCheck(ctx context.Context, dir string) readyCheck {
+	c := readyCheck{Name: "Pushed to upstream", fixKey: keys.FixPush, fixLabel: "push", fix: RunPushTUI}
+	upstream, err := git.Upstream(ctx, dir)
+	if err != nil {
+		c.Detail = "no upstream configured"
+		return c
+	}
+	ahead, _, err := git.AheadBehind(ctx, dir, upstream, "HEAD")
+	if err != nil {
+		c.Detail = err.Error()
+		return c
+	}
+	c.Passed = ahead == 0
+	if ahead > 0 {
+		c.Detail = fmt.Sprintf("%d commits not pushed to %s", ahead, upstream)
+	}
+	return c
+}
+
+// commitMessages returns the full messages of the commits in rng, newest first.
+func commitMessages(ctx context.Context, dir, rng string) ([]string, error) {
+	out, err := git.RunCombined(ctx, dir, "log", "--format=%B%x00", rng)
+	if err != nil {
+		return nil, err
+	}
+	var msgs []string
+	for _, m := range strings.Split(out, "\x00") {
+		if m = strings.TrimSpace(m); m != "" {
+			msgs = append(msgs, m)
+		}
+	}
+	return msgs, nil
+}
+
+// lintCommitMessage returns the style problems found in a commit message.
+func lintCommitMessage(msg string) []string {
+	var problems []string
+	lines := strings.Split(msg, "\n")
+	subject := strings.TrimSpace(lines[0])
+	switch {
+	case subject == "":
+		problems = append(problems, "empty subject")
+	case len(subject) > 72:
+		problems = append(problems, "subject longer than 72 characters")
+	}
+	if strings.HasSuffix(subject, ".") {
+		problems = append(problems, "subject ends with a period")
+	}
+	if lower := strings.ToLower(subject); strings.HasPrefix(lower, "wip") {
+		problems = append(problems, "work-in-progress commit")
+	}
+	if len(lines) > 1 && strings.TrimSpace(lines[1]) != "" {
+		problems = append(problems, "missing blank line after subject")
+	}
+	return problems
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
+
+// scanAddedLines looks through lines added since base for conflict markers and debug
+// statements, returning "file:line" locations for each.
+func scanAddedLines(ctx context.Context, dir, base string, debugPatterns []string) (markers []string, debug []string, err error) {
+	var debugRes []*regexp.Regexp
+	for _, p := range debugPatterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid debug pattern %q: %w", p, err)
+		}
+		debugRes = append(debugRes, re)
+	}
+
+	out, err := git.RunCombined(ctx, dir, "diff", "-U0", "--no-color", base, "HEAD")
+	if err != nil {
+		return nil, nil, err
+	}
+	hunkRe := regexp.MustCompile(`^@@ -\S+ \+(\d+)`)
+	file := ""
+	line := 0
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	for scanner.Scan() {
+		ln := scanner.Text()
+		switch {
+		case strings.HasPrefix(ln, "+++ "):
+			file = strings.TrimPrefix(strings.TrimPrefix(ln, "+++ "), "b/")
+		case strings.HasPrefix(ln, "@@"):
+			if m := hunkRe.FindStringSubmatch(ln); m != nil {
+				line, _ = strconv.Atoi(m[1])
+			}
+		case strings.HasPrefix(ln, "+"):
+			added := ln[1:]
+			loc := fmt.Sprintf("%s:%d", file, line)
+			if conflictMarkerRe.MatchString(added) {
+				markers = append(markers, loc)
+			}
+			for _, re := range debugRes {
+				if re.MatchString(added) {
+					debug = append(debug, loc)
+					break
+				}
+			}
+			line++
+		}
+	}
+	return markers, debug, scanner.Err()
+}
+
+// diffSize returns the number of added plus removed lines since base.
+func diffSize(ctx context.Context, dir, base string) (int, error) {
+	out, err := git.RunCombined(ctx, dir, "diff", "--numstat", base, "HEAD")
+	if err != nil {
+		return 0, err
+	}
+	total := 0
+	for _, ln := range strings.Split(out, "\n") {
+		f := strings.Fields(ln)
+		if len(f) < 3 {
+			continue
+		}
+		// binary files report "-" and are not counted
+		added, _ := strconv.Atoi(f[0])
+		removed, _ := strconv.Atoi(f[1])
+		total += added + removed
+	}
+	return total, nil
+}
+
+// ---------------- Ready Model ----------------
+
+type readyModel struct {
+	report readyReport
+	fix    *readyCheck
+	help   helpOverlay
+	done   bool
+}
+
+func newReadyModel(r readyReport) readyModel {
+	return readyModel{report: r}
+}
+
+func (m readyModel) Init() tea.Cmd { return nil }
+
+func (m readyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		var handled bool
+		if m.help, handled = m.help.update(msg); handled {
+			return m, nil
+		}
+		if key.Matches(msg, keys.Quit, keys.Select, interrupt) {
+			m.done = true
+			return m, tea.Quit
+		}
+		for i, c := range m.report.Checks {
+			if !c.Passed && c.fix != nil && key.Matches(msg, c.fixKey) {
+				m.fix = &m.report.Checks[i]
+				m.done = true
+				return m, tea.Quit
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m readyModel) View() string {
+	if m.help.open {
+		return m.help.View(m.report.fixes(), []key.Binding{keys.Quit, keys.Help})
+	}
+	return m.report.render(true) + "\n" + shortHelp(keys.Quit, keys.Help)
+}
+
+// fixes are the keys of the one-key fixes of the failing checks.
+func (r readyReport) fixes() []key.Binding {
+	var fixes []key.Binding
+	seen := map[string]bool{}
+	for _, c := range r.Checks {
+		if c.Passed || c.fix == nil || !c.fixKey.Enabled() || seen[c.fixKey.Help().Key] {
+			continue
+		}
+		seen[c.fixKey.Help().Key] = true
+		b := c.fixKey
+		b.SetHelp(b.Help().Key, c.fixLabel)
+		fixes = append(fixes, b)
+	}
+	return fixes
+}
+
+// render draws the checklist; withFixes lists the one-key fixes of the TUI.
+func (r readyReport) render(withFixes bool) string {
+	pass := theme.good()
+	fail := theme.bad()
+	dim := theme.muted()
+
+	s := fmt.Sprintf("GitMate: Is '%s' ready for review?\n\n", r.Branch)
+	for _, c := range r.Checks {
+		if c.Passed {
+			s += pass.Render("✅ "+c.Name) + "\n"
+		} else {
+			s += fail.Render("❌ "+c.Name) + "\n"
+		}
+		if c.Detail != "" {
+			s += dim.Render("   "+c.Detail) + "\n"
+		}
+	}
+	if r.Ready {
+		s += "\n🚀 Ready to open a pull request.\n"
+	} else if fixes := r.fixes(); withFixes && len(fixes) > 0 {
+		s += "\nFixes: " + shortHelp(fixes...) + "\n"
+	}
+	return s
+}
+
+// ---------------- Public Entry ----------------
+
+// RunReadyTUI evaluates the checklist and lets the user apply one-key fixes,
+// re-evaluating after each fix. Headless it prints the report instead.
+func RunReadyTUI(ctx context.Context) error {
+	return runApp(ctx, readyFlow)
+}
+
+// readyFlow shows the checklist until it passes or the user leaves it.
+func readyFlow(ctx context.Context) error {
+	cfg, err := config.Load(ctx, ".")
+	if err != nil {
+		return err
+	}
+	for {
+		report, err := evaluateReady(ctx, ".", cfg)
+		if err != nil {
+			return err
+		}
+		if mode.Headless {
+			if jsonOutput() {
+				output.data(report)
+			} else {
+				fmt.Print(report.render(false))
+			}
+			if !report.Ready {
+				return failure.Errorf(failure.Checks, "branch %s is not ready", report.Branch)
+			}
+			return nil
+		}
+
+		final, err := show(newReadyModel(report))
+		if err != nil {
+			return err
+		}
+		m, ok := final.(readyModel)
+		if !ok || m.fix == nil {
+			return nil
+		}
+		if err := m.fix.fix(ctx); err != nil {
+			return err
+		}
+	}
+}
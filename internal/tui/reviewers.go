@@ -0,0 +1,310 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/codeowners"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/config"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/git"
+)
+
+// ReviewersOptions configures `gitmate reviewers`.
+type ReviewersOptions struct {
+	Base  string // compare against this branch; trunk when empty
+	Limit int    // how many suggestions to show
+}
+
+// ---------------- Ranking ----------------
+
+// reviewerSuggestion is one ranked candidate reviewer.
+type reviewerSuggestion struct {
+	Reviewer string   `json:"reviewer"`         // @handle, @org/team or "Name <email>"
+	Handle   string   `json:"handle,omitempty"` // username to request on the hosting service
+	Team     bool     `json:"team,omitempty"`
+	Score    int      `json:"score"`
+	Reasons  []string `json:"reasons"`
+}
+
+// weights for the signals; code ownership is an explicit statement, history a hint
+const (
+	ownerWeight     = 10 // per changed file owned
+	commitWeight    = 2  // per recent commit touching a changed file
+	blameLinesPerPt = 20 // lines of blame per point
+	maxBlameFiles   = 25
+)
+
+// suggestReviewers ranks people for reviewing the changes between base and HEAD
+// using CODEOWNERS and the recent history of the changed files.
+func suggestReviewers(ctx context.Context, dir, base string) ([]reviewerSuggestion, []string, error) {
+	mergeBase, err := git.MergeBase(ctx, dir, base, "HEAD")
+	if err != nil {
+		return nil, nil, err
+	}
+	files, err := git.DiffNames(ctx, dir, mergeBase, "HEAD")
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(files) == 0 {
+		return nil, nil, nil
+	}
+
+	byKey := map[string]*reviewerSuggestion{}
+	get := func(key, name string) *reviewerSuggestion {
+		if s, ok := byKey[key]; ok {
+			return s
+		}
+		s := &reviewerSuggestion{Reviewer: name}
+		byKey[key] = s
+		return s
+	}
+	me := strings.ToLower(selfEmail(ctx, dir))
+
+	// CODEOWNERS: count the changed files each owner is responsible for
+	root, _ := git.RunCombined(ctx, dir, "rev-parse", "--show-toplevel")
+	owners, err := codeowners.Find(root)
+	if err != nil {
+		return nil, nil, err
+	}
+	if owners != nil {
+		owned := map[string][]string{} // owner -> files
+		patterns := map[string]map[string]bool{}
+		for _, f := range files {
+			for _, rule := range owners.Match(f) {
+				for _, o := range rule.Owners {
+					owned[o] = append(owned[o], f)
+					if patterns[o] == nil {
+						patterns[o] = map[string]bool{}
+					}
+					patterns[o][rule.Pattern] = true
+				}
+			}
+		}
+		for o, fs := range owned {
+			if strings.ToLower(o) == me {
+				continue
+			}
+			s := get(ownerKey(o), o)
+			if handle, ok := strings.CutPrefix(o, "@"); ok {
+				s.Handle = handle
+				s.Team = strings.Contains(handle, "/")
+			}
+			s.Score += ownerWeight * len(fs)
+			s.Reasons = append(s.Reasons, fmt.Sprintf("code owner of %s (%s in %s)",
+				countNoun(len(fs), "changed file"), strings.Join(sortedKeys(patterns[o]), ", "), owners.Path))
+		}
+	}
+
+	// history: recent commits to the changed files, and who wrote the lines being changed
+	commits, err := recentAuthors(ctx, dir, mergeBase, files)
+	if err != nil {
+		return nil, nil, err
+	}
+	for email, a := range commits {
+		if email == me {
+			continue
+		}
+		s := get(ownerKey(email), a.name+" <"+a.email+">")
+		if s.Handle == "" {
+			s.Handle = noreplyLogin(email)
+		}
+		s.Score += commitWeight * a.commits
+		s.Reasons = append(s.Reasons, fmt.Sprintf("%s to %s in the last 6 months",
+			countNoun(a.commits, "commit"), countNoun(len(a.files), "changed file")))
+	}
+	for email, b := range blameAuthors(ctx, dir, mergeBase, files) {
+		if email == me || b.lines < blameLinesPerPt {
+			continue
+		}
+		s := get(ownerKey(email), b.name+" <"+b.email+">")
+		if s.Handle == "" {
+			s.Handle = noreplyLogin(email)
+		}
+		s.Score += b.lines / blameLinesPerPt
+		s.Reasons = append(s.Reasons, fmt.Sprintf("wrote %d lines of the files you changed", b.lines))
+	}
+
+	res := make([]reviewerSuggestion, 0, len(byKey))
+	for _, s := range byKey {
+		res = append(res, *s)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Score != res[j].Score {
+			return res[i].Score > res[j].Score
+		}
+		return res[i].Reviewer < res[j].Reviewer
+	})
+	return res, files, nil
+}
+
+// ownerKey identifies a person across CODEOWNERS and history: email owners and
+// commit authors share their lower-cased address.
+func ownerKey(owner string) string {
+	return strings.ToLower(owner)
+}
+
+// noreplyLogin extracts the GitHub login from a users.noreply.github.com address.
+func noreplyLogin(email string) string {
+	local, ok := strings.CutSuffix(email, "@users.noreply.github.com")
+	if !ok {
+		return ""
+	}
+	if _, login, found := strings.Cut(local, "+"); found {
+		return login
+	}
+	return local
+}
+
+func selfEmail(ctx context.Context, dir string) string {
+	out, _ := git.RunCombined(ctx, dir, "config", "user.email")
+	return out
+}
+
+type authorStats struct {
+	name, email string
+	commits     int
+	lines       int
+	files       map[string]bool
+}
+
+// recentAuthors counts non-merge commits per author that touched files in the six
+// months before mergeBase (the branch's own commits are excluded).
+func recentAuthors(ctx context.Context, dir, mergeBase string, files []string) (map[string]*authorStats, error) {
+	args := append([]string{"log", "--no-merges", "--since=6.months", "--format=%x01%aN%x00%aE", "--name-only", mergeBase, "--"}, files...)
+	out, err := git.RunCombined(ctx, dir, args...)
+	if err != nil {
+		return nil, err
+	}
+	changed := map[string]bool{}
+	for _, f := range files {
+		changed[f] = true
+	}
+	stats := map[string]*authorStats{}
+	var cur *authorStats
+	for _, line := range strings.Split(out, "\n") {
+		if rest, ok := strings.CutPrefix(line, "\x01"); ok {
+			name, email, _ := strings.Cut(rest, "\x00")
+			key := strings.ToLower(email)
+			if stats[key] == nil {
+				stats[key] = &authorStats{name: name, email: email, files: map[string]bool{}}
+			}
+			cur = stats[key]
+			cur.commits++
+			continue
+		}
+		if cur != nil && changed[line] {
+			cur.files[line] = true
+		}
+	}
+	return stats, nil
+}
+
+// blameAuthors counts, per author, the lines at mergeBase of the changed files.
+// Files that did not exist there (new files) are skipped.
+func blameAuthors(ctx context.Context, dir, mergeBase string, files []string) map[string]*authorStats {
+	stats := map[string]*authorStats{}
+	for i, f := range files {
+		if i == maxBlameFiles {
+			break
+		}
+		out, err := git.RunCombined(ctx, dir, "blame", "--line-porcelain", "-w", mergeBase, "--", f)
+		if err != nil {
+			continue
+		}
+		var name string
+		for _, line := range strings.Split(out, "\n") {
+			if v, ok := strings.CutPrefix(line, "author "); ok {
+				name = v
+			} else if v, ok := strings.CutPrefix(line, "author-mail "); ok {
+				email := strings.Trim(v, "<>")
+				key := strings.ToLower(email)
+				if stats[key] == nil {
+					stats[key] = &authorStats{name: name, email: email}
+				}
+				stats[key].lines++
+			}
+		}
+	}
+	return stats
+}
+
+func countNoun(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// suggestedHandles returns up to n individual usernames from the ranking, in order,
+// for requesting reviews. Teams and people without a known username are skipped.
+func suggestedHandles(suggestions []reviewerSuggestion, n int) []string {
+	var res []string
+	for _, s := range suggestions {
+		if len(res) == n {
+			break
+		}
+		if s.Handle != "" && !s.Team {
+			res = append(res, s.Handle)
+		}
+	}
+	return res
+}
+
+// ---------------- Public Entry ----------------
+
+// RunReviewers prints the ranked reviewer suggestions for the current branch.
+func RunReviewers(ctx context.Context, opts ReviewersOptions) error {
+	cfg, err := config.Load(ctx, ".")
+	if err != nil {
+		return err
+	}
+	base := opts.Base
+	if base == "" {
+		base = git.TrunkRef(ctx, ".", cfg.Trunk)
+	}
+	suggestions, files, err := suggestReviewers(ctx, ".", base)
+	if err != nil {
+		return err
+	}
+	if opts.Limit > 0 && len(suggestions) > opts.Limit {
+		suggestions = suggestions[:opts.Limit]
+	}
+
+	if jsonOutput() {
+		if suggestions == nil {
+			suggestions = []reviewerSuggestion{}
+		}
+		output.data(suggestions)
+		return nil
+	}
+	if len(files) == 0 {
+		fmt.Printf("No changes against %s.\n", base)
+		return nil
+	}
+	if len(suggestions) == 0 {
+		fmt.Printf("No reviewer suggestions for %s changed against %s.\n", countNoun(len(files), "file"), base)
+		return nil
+	}
+	fmt.Printf("Suggested reviewers for %s changed against %s:\n\n", countNoun(len(files), "file"), base)
+	for i, s := range suggestions {
+		fmt.Printf("%d. %s (score %d)\n", i+1, s.Reviewer, s.Score)
+		for _, r := range s.Reasons {
+			fmt.Printf("     - %s\n", r)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package tui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/workflow"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ---------------- Rollback ----------------
+
+// snapshot records where the current repository stands before a workflow runs;
+// nil when it can't, e.g. before the first commit.
+func snapshot(ctx context.Context) *workflow.Snapshot {
+	s, err := workflow.Capture(ctx, ".")
+	if err != nil {
+		return nil
+	}
+	return &s
+}
+
+// rollbackAfter brings the repository back to snap when a workflow failed with
+// err. run is the final model of the workflow; the rollbacks of its completed
+// steps come first. The TUI asks first; headless, it rolls back right away.
+// What was undone is reported, and err is returned as it was.
+func rollbackAfter(ctx context.Context, snap *workflow.Snapshot, run tea.Model, err error) error {
+	if err == nil {
+		return err
+	}
+	// the rollback also runs after ctrl+c stopped the workflow
+	ctx = context.WithoutCancel(ctx)
+	var steps []workflow.Undo
+	if m, ok := run.(interface{ rollbacks() []workflow.Undo }); ok {
+		steps = m.rollbacks()
+	}
+	undo := steps
+	if snap != nil {
+		undo = append(slices.Clip(undo), snap.Undo(ctx, ".")...)
+	}
+	if len(undo) == 0 {
+		return err
+	}
+	if !mode.Headless {
+		body := "GitMate: The workflow stopped half-way. To put things back as they were it will:\n\n"
+		for _, u := range undo {
+			body += " ↩ " + u.What + "\n"
+		}
+		if yes, perr := confirm(body+"\n", "Roll back?"); perr != nil || !yes {
+			return err
+		}
+	}
+
+	r := &workflow.Runner{Dir: ".", Emit: func(e workflow.Event) {
+		logEvent(e)
+		if !mode.Headless {
+			return
+		}
+		switch e.Kind {
+		case workflow.CommandStarted:
+			output.step(e.Command)
+		case workflow.CommandOutput:
+			if e.Stderr {
+				output.line("[stderr] " + e.Line)
+			} else {
+				output.line(e.Line)
+			}
+		case workflow.CommandDone:
+			output.stepDone(e.Command, e.Duration, e.ExitCode)
+		}
+	}}
+	var done []string
+	stopped := ""
+	restore := func(undo []workflow.Undo) bool {
+		d, rerr := r.Restore(ctx, undo)
+		done = append(done, d...)
+		if rerr != nil {
+			stopped = fmt.Sprintf("rollback stopped before it could %s: %v", undo[len(d)].What, rerr)
+		}
+		return rerr == nil
+	}
+	if restore(steps) && snap != nil {
+		// the step rollbacks may have done part of it already
+		restore(snap.Undo(ctx, "."))
+	}
+	output.rolledBack(done)
+	if len(done) > 0 {
+		say("Rolled back to where you started:\n")
+		for _, d := range done {
+			say(" ↩ %s\n", d)
+		}
+	}
+	if stopped != "" {
+		output.warn(stopped)
+	}
+	return err
+}
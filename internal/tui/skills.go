@@ -0,0 +1,165 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/config"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/git"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/lesson"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ---------------- Report ----------------
+
+// progressReport is the exported view of a learner's progress. Team leads collect
+// these (`gitmate tutor progress --output json`) to track onboarding.
+type progressReport struct {
+	Learner     string         `json:"learner"`
+	GeneratedAt time.Time      `json:"generatedAt"`
+	Skills      []skillReport  `json:"skills"`
+	Lessons     []lessonReport `json:"lessons"`
+}
+
+type skillReport struct {
+	Skill     string `json:"skill"`
+	Completed int    `json:"completed"` // lessons exercising the skill that were completed
+	Total     int    `json:"total"`
+}
+
+type lessonReport struct {
+	ID               string     `json:"id"`
+	Title            string     `json:"title"`
+	Level            string     `json:"level,omitempty"`
+	Skills           []string   `json:"skills"`
+	Completed        bool       `json:"completed"`
+	CompletedAt      *time.Time `json:"completedAt,omitempty"`
+	StepsDone        int        `json:"stepsDone"`
+	Steps            int        `json:"steps"`
+	Attempts         int        `json:"attempts"`
+	HintsUsed        int        `json:"hintsUsed"`
+	BestScore        int        `json:"bestScore"`
+	TimeSpentSeconds int64      `json:"timeSpentSeconds"`
+	LastActivity     *time.Time `json:"lastActivity,omitempty"`
+}
+
+// buildProgressReport joins the lesson catalogue with the saved progress.
+func buildProgressReport(ctx context.Context, lessons []lesson.Lesson, progress *lesson.Progress) progressReport {
+	learner, _ := git.RunCombined(ctx, ".", "config", "user.email")
+	r := progressReport{Learner: learner, GeneratedAt: time.Now().UTC()}
+
+	skills := map[string]*skillReport{}
+	for _, l := range lessons {
+		lr := lessonReport{ID: l.ID, Title: l.Title, Level: l.Level, Skills: l.Skills, Steps: len(l.Steps)}
+		if lp, ok := progress.Lessons[l.ID]; ok {
+			lr.Completed, lr.CompletedAt = lp.Completed, lp.CompletedAt
+			lr.StepsDone = lp.StepsDone()
+			lr.Attempts, lr.HintsUsed, lr.TimeSpentSeconds = lp.Attempts, lp.HintsUsed, lp.TimeSpent
+			lr.BestScore = lp.BestScore
+			if !lp.LastActivity.IsZero() {
+				last := lp.LastActivity
+				lr.LastActivity = &last
+			}
+		}
+		if lr.Skills == nil {
+			lr.Skills = []string{}
+		}
+		r.Lessons = append(r.Lessons, lr)
+
+		for _, sk := range l.Skills {
+			if skills[sk] == nil {
+				skills[sk] = &skillReport{Skill: sk}
+			}
+			skills[sk].Total++
+			if lr.Completed {
+				skills[sk].Completed++
+			}
+		}
+	}
+	for _, sk := range skills {
+		r.Skills = append(r.Skills, *sk)
+	}
+	sort.Slice(r.Skills, func(i, j int) bool { return r.Skills[i].Skill < r.Skills[j].Skill })
+	return r
+}
+
+// renderProgress draws the skill map and lesson list.
+func renderProgress(r progressReport) string {
+	head := lipgloss.NewStyle().Bold(true)
+	dim := theme.muted()
+	filled := theme.accent()
+	const barWidth = 12
+
+	s := "GitMate: Tutor progress"
+	if r.Learner != "" {
+		s += " (" + r.Learner + ")"
+	}
+	s += "\n\n" + head.Render("Skills") + "\n"
+	if len(r.Skills) == 0 {
+		s += dim.Render("  no lessons declare skills") + "\n"
+	}
+	for _, sk := range r.Skills {
+		n := barWidth * sk.Completed / sk.Total
+		bar := filled.Render(strings.Repeat("█", n)) + dim.Render(strings.Repeat("░", barWidth-n))
+		s += fmt.Sprintf("  %-14s %s %d/%d\n", sk.Skill, bar, sk.Completed, sk.Total)
+	}
+
+	s += "\n" + head.Render("Lessons") + "\n"
+	for _, l := range r.Lessons {
+		icon, note := "○", ""
+		switch {
+		case l.StepsDone > 0 && l.StepsDone < l.Steps:
+			icon = "◐"
+			note = fmt.Sprintf("%d/%d steps · resume with `gitmate tutor %s`", l.StepsDone, l.Steps, l.ID)
+		case l.Completed:
+			icon = "●"
+			note = countNoun(l.Attempts, "attempt")
+			if l.BestScore > 0 {
+				note += fmt.Sprintf(" · best score %d", l.BestScore)
+			}
+			if l.HintsUsed > 0 {
+				note += " · " + countNoun(l.HintsUsed, "hint")
+			}
+		}
+		if l.TimeSpentSeconds > 0 {
+			if note != "" {
+				note += " · "
+			}
+			note += (time.Duration(l.TimeSpentSeconds) * time.Second).String()
+		}
+		s += fmt.Sprintf("  %s %-16s %-34s %s\n", icon, l.ID, l.Title, dim.Render(note))
+	}
+	return s
+}
+
+// ---------------- Public Entry ----------------
+
+// RunTutorProgress shows the learner's skill map; with --output json it is the
+// data of the result.
+func RunTutorProgress(ctx context.Context) error {
+	cfg, err := config.Load(ctx, ".")
+	if err != nil {
+		return err
+	}
+	lessons, err := loadLessons(cfg)
+	if err != nil {
+		return err
+	}
+	progress, err := lesson.LoadProgress()
+	if err != nil {
+		return fmt.Errorf("reading tutor progress: %w", err)
+	}
+	r := buildProgressReport(ctx, lessons, progress)
+	if jsonOutput() {
+		output.data(r)
+		return nil
+	}
+	fmt.Print(renderProgress(r))
+	return nil
+}
@@ -6,13 +6,16 @@ package tui
 import (
 	"context"
 	"fmt"
-	"github.com/charmbracelet/bubbles/list"
-	"github.com/charmbracelet/lipgloss"
 	"regexp"
 	"strings"
 
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/config"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/failure"
 	"github.com/Orctatech-Engineering-Team/GitMate/internal/git"
-	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/tracker"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/workflow"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -35,14 +38,6 @@ type promptModel struct {
 }
 
 func newPromptModel() promptModel {
-	// Delegate
-	d := list.NewDefaultDelegate()
-
-	// Change colors
-	c := lipgloss.Color("#6f03fc")
-	d.Styles.SelectedTitle = d.Styles.SelectedTitle.Foreground(c).BorderLeftForeground(c)
-	d.Styles.SelectedDesc = d.Styles.SelectedTitle // reuse title style
-
 	// Items
 	items := []list.Item{
 		choiceStash,
@@ -51,8 +46,7 @@ func newPromptModel() promptModel {
 		choiceQuit,
 	}
 
-	l := list.New(items, d, 80, 10)
-	l.Title = "Uncommitted changes detected. What do you want to do?"
+	l := newList(items, Desc, 80, 10)
 
 	return promptModel{list: l}
 }
@@ -62,15 +56,15 @@ func (m promptModel) Init() tea.Cmd { return nil }
 func (m promptModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "enter":
+		switch {
+		case key.Matches(msg, keys.Select):
 			i, ok := m.list.SelectedItem().(listItem)
 			if ok {
 				m.choice = i
 				m.done = true
 				return m, tea.Quit
 			}
-		case "q", "ctrl+c":
+		case key.Matches(msg, keys.Quit, interrupt):
 			m.choice = choiceQuit
 			m.done = true
 			return m, tea.Quit
@@ -106,9 +100,10 @@ type branchInputModel struct {
 	branch    string
 }
 
-func newBranchInputModel() branchInputModel {
+func newBranchInputModel(initial string) branchInputModel {
 	ti := textinput.New()
 	ti.Placeholder = "Enter feature branch name..."
+	ti.SetValue(initial)
 	ti.Focus()
 	ti.CharLimit = 64
 	ti.Width = 30
@@ -120,12 +115,12 @@ func (m branchInputModel) Init() tea.Cmd { return textinput.Blink }
 func (m branchInputModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "enter":
+		switch {
+		case key.Matches(msg, keys.Select):
 			m.done = true
 			m.branch = m.textInput.Value()
 			return m, tea.Quit
-		case "ctrl+c":
+		case key.Matches(msg, interrupt):
 			m.done = true
 			return m, tea.Quit
 		}
@@ -136,80 +131,32 @@ func (m branchInputModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m branchInputModel) View() string {
-	return fmt.Sprintf(
-		"Feature branch name:\n%s\n\n(press enter to continue, ctrl+c to quit)",
-		m.textInput.View(),
-	)
+	next := keys.Select
+	next.SetHelp(next.Help().Key, "continue")
+	return fmt.Sprintf("Feature branch name:\n%s\n\n%s", m.textInput.View(), shortHelp(next, interrupt))
 }
 
 // ---------------- Start Model ----------------
 
 type startModel struct {
-	spinner spinner.Model
-	logs    []string
-	err     error
-	done    bool
-	branch  string
-}
-
-func newStartModel(branch string) startModel {
-	s := spinner.New()
-	s.Spinner = spinner.Dot
-	return startModel{
-		spinner: s,
-		branch:  branch,
-	}
+	runModel
+	branch string
 }
 
-func (m startModel) Init() tea.Cmd {
-	return m.spinner.Tick
+func newStartModel(ctx context.Context, w workflow.Workflow, branch string) startModel {
+	m := startModel{runModel: newRunModel(ctx, fmt.Sprintf("Starting new feature branch '%s'", branch), w, nil), branch: branch}
+	m.quitOnDone = true
+	return m
 }
 
 func (m startModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		if msg.String() == "q" || msg.String() == "ctrl+c" {
-			return m, tea.Quit
-		}
-	case spinner.TickMsg:
-		if !m.done {
-			var cmd tea.Cmd
-			m.spinner, cmd = m.spinner.Update(msg)
-			return m, cmd
-		}
-	case gitLineMsg:
-		m.logs = append(m.logs, string(msg))
-	case gitErrMsg:
-		m.err = msg
-		m.done = true
-		return m, tea.Quit
-	case gitDoneMsg:
-		m.done = true
-		return m, tea.Quit
-	}
-	return m, nil
+	var cmd tea.Cmd
+	m.runModel, cmd = m.update(msg)
+	return m, cmd
 }
 
 func (m startModel) View() string {
-	s := fmt.Sprintf("GitMate: Starting new feature branch '%s'\n\n", m.branch)
-	if m.err != nil {
-		s += "Error: " + m.err.Error() + "\n"
-	} else if m.done {
-		s += fmt.Sprintf("✅ Branch feature/%s created and checked out.\n\n", m.branch)
-	} else {
-		s += m.spinner.View() + " Running git commands...\n\n"
-	}
-	start := 0
-	if len(m.logs) > 10 {
-		start = len(m.logs) - 10
-	}
-	for _, line := range m.logs[start:] {
-		s += line + "\n"
-	}
-	if m.done {
-		s += "\n(press q to quit)"
-	}
-	return s
+	return m.view("Running git commands...", fmt.Sprintf("Branch feature/%s created and checked out.", m.branch))
 }
 
 // ---------------- Helpers ----------------
@@ -220,27 +167,191 @@ func sanitizeBranchName(name string) string {
 	return re.ReplaceAllString(name, "-")
 }
 
+// issueSlug builds a branch name from an issue key and title, e.g.
+// PROJ-123 "Add login form" → proj-123-add-login-form.
+func issueSlug(key, title string) string {
+	slug := sanitizeBranchName(strings.TrimPrefix(key, "#") + " " + title)
+	if len(slug) > 48 {
+		slug = slug[:48]
+		if i := strings.LastIndex(slug, "-"); i > len(key) {
+			slug = slug[:i]
+		}
+	}
+	return strings.Trim(slug, "-.")
+}
+
+// lookupIssue fetches key from its tracker. The tracker's client gives up after
+// the configured request timeout when it is unreachable.
+func lookupIssue(ctx context.Context, key string, cfg config.Config) (*tracker.Issue, error) {
+	t, err := tracker.ForKey(ctx, ".", key, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return t.Issue(ctx, key)
+}
+
+// prepareCommitMsgHook prefixes commit messages with the branch's linked issue key.
+const prepareCommitMsgHook = `#!/bin/sh
+` + git.HookMarker + `: prefixes commit messages with the linked issue key
+command -v gitmate >/dev/null 2>&1 || exit 0
+exec gitmate hook prepare-commit-msg "$@"
+`
+
+// linkIssue stores the issue on the new branch and installs the commit-msg hook.
+func linkIssue(ctx context.Context, log func(string), branch string, issue tracker.Issue) {
+	if err := tracker.Link(ctx, ".", branch, issue); err != nil {
+		log("[warn] could not link " + issue.Key + ": " + err.Error())
+		return
+	}
+	log(fmt.Sprintf("Linked %s to %s", issue.Key, branch))
+	if err := git.InstallHook(ctx, ".", "prepare-commit-msg", prepareCommitMsgHook); err != nil {
+		log("[warn] " + err.Error() + "; add `gitmate hook prepare-commit-msg \"$@\"` to it to prefix commits with " + issue.Key)
+	}
+}
+
 // ---------------- Orchestration ----------------
 
-// runStart orchestrates checkout main → pull → create feature branch with live logs
-func runStart(p *tea.Program, branch string) {
-	streamStep(p, "checkout", []string{"main"}, func() {
-		streamStep(p, "pull", []string{"origin", "main"}, func() {
-			safe := sanitizeBranchName(branch)
-			streamStep(p, "checkout", []string{"-b", "feature/" + safe}, func() {
-				p.Send(gitDoneMsg{})
-			})
+// startWorkflow checks out trunk, pulls it and creates the feature branch.
+// When the branch was started from an issue, the issue is linked to it.
+func startWorkflow(trunk, branch string, issue *tracker.Issue) workflow.Workflow {
+	feature := "feature/" + sanitizeBranchName(branch)
+	// no step rollbacks: the rollback to the snapshot takes the user back
+	w := workflow.Workflow{Name: "start", Steps: []workflow.Step{
+		{
+			Name:    "Switch to " + trunk,
+			Explain: "New work starts from the trunk.",
+			Git:     []string{"checkout", trunk},
+		},
+		{
+			Name:    "Update " + trunk,
+			Explain: "Pull the latest commits so the branch starts from what everyone else has.",
+			Git:     []string{"pull", "--progress", "origin", trunk},
+			Retries: 2,
+		},
+		{
+			Name: "Create " + feature,
+			Git:  []string{"checkout", "-b", feature},
+		},
+	}}
+	if issue != nil {
+		w.Steps = append(w.Steps, workflow.Step{
+			Name: "Link " + issue.Key,
+			Do: func(ctx context.Context, log func(string)) error {
+				linkIssue(ctx, log, feature, *issue)
+				return nil
+			},
 		})
-	})
+	}
+	return w
+}
+
+// resolveDirtyTree asks the user how to handle uncommitted changes (stash, commit
+// or discard) when the working tree is dirty. It returns false if the user quit.
+// --on-dirty answers the question up front; headless, a missing answer is an error.
+func resolveDirtyTree(ctx context.Context) (bool, error) {
+	dirty, err := git.IsDirty(ctx, ".")
+	if err != nil {
+		return false, err
+	}
+	if !dirty {
+		return true, nil
+	}
+	choice, err := dirtyChoice()
+	if err != nil {
+		return false, err
+	}
+	switch choice {
+	case choiceStash:
+		_, err = git.RunCombined(ctx, ".", "stash", "push", "-u")
+	case choiceCommit:
+		_, err = git.RunCombined(ctx, ".", "add", "-A")
+		if err == nil && mode.Headless {
+			_, err = git.RunCombined(ctx, ".", "commit", "-m", "Save uncommitted changes")
+		} else if err == nil {
+			// Open Git editor for commit message
+			_, err = git.RunCombined(ctx, ".", "commit")
+		}
+	case choiceDiscard:
+		_, err = git.RunCombined(ctx, ".", "reset", "--hard")
+	case choiceQuit:
+		if mode.OnDirty == "abort" {
+			return false, failure.Errorf(failure.Dirty, "working tree has uncommitted changes")
+		}
+		return false, nil
+	}
+	if err != nil {
+		return false, failure.New(failure.Git, err)
+	}
+	return true, nil
+}
+
+// dirtyChoice returns the answer given with --on-dirty, or asks for one.
+func dirtyChoice() (listItem, error) {
+	switch mode.OnDirty {
+	case "stash":
+		return choiceStash, nil
+	case "commit":
+		return choiceCommit, nil
+	case "discard":
+		return choiceDiscard, nil
+	case "abort":
+		return choiceQuit, nil
+	}
+	if mode.Headless {
+		return choiceQuit, failure.Errorf(failure.Dirty,
+			"working tree has uncommitted changes; pass --on-dirty=%s", strings.Join(DirtyActions, "|"))
+	}
+	// Run prompt for stash/commit/discard
+	final, err := show(newPromptModel())
+	if err != nil {
+		return choiceQuit, err
+	}
+	if p, ok := final.(promptModel); ok {
+		return p.choice, nil
+	}
+	return choiceQuit, nil
 }
 
 // ---------------- Public Entry ----------------
 
-func RunStartTUI(featureName string) error {
+// RunStartTUI starts a feature branch. featureName may be a branch name or an issue
+// key (PROJ-123, #45), in which case the branch is named after the issue's title.
+func RunStartTUI(ctx context.Context, featureName string) error {
+	return runApp(ctx, func(ctx context.Context) error { return startFlow(ctx, featureName) })
+}
+
+// startFlow names the branch, deals with uncommitted changes and creates it.
+func startFlow(ctx context.Context, featureName string) error {
+	// 0. Resolve an issue key to a branch name. When the tracker is unreachable
+	// the branch is named after the key: headless as it is, in the TUI by
+	// finishing the name in the prompt.
+	var issue *tracker.Issue
+	var prefill string
+	cfg, err := config.Load(ctx, ".")
+	if err != nil {
+		return err
+	}
+	if key, ok := tracker.ParseKey(featureName, cfg); ok {
+		issue = &tracker.Issue{Key: key}
+		if found, err := lookupIssue(ctx, key, cfg); err != nil {
+			say("Could not fetch %s from the issue tracker (%v).\nNaming the branch after the key.\n", key, err)
+			featureName = issueSlug(key, "")
+			if !mode.Headless {
+				featureName, prefill = "", issueSlug(key, "")+"-"
+			}
+		} else {
+			found.Key = key
+			issue = found
+			featureName = issueSlug(key, found.Title)
+		}
+	}
+
 	// 1. Prompt for branch name if none provided
+	if featureName == "" && mode.Headless {
+		return needsAnswer("the branch name", "it as an argument")
+	}
 	if featureName == "" {
-		tiProgram := tea.NewProgram(newBranchInputModel())
-		final, err := tiProgram.Run()
+		final, err := show(newBranchInputModel(prefill))
 		if err != nil {
 			return err
 		}
@@ -248,46 +359,36 @@ func RunStartTUI(featureName string) error {
 			featureName = m.branch
 		}
 		if featureName == "" {
-			return fmt.Errorf("no branch name provided")
+			return failure.Errorf(failure.Usage, "no branch name provided")
 		}
 	}
 
-	// 2. Check if repo is dirty
-	dirty, err := git.IsDirty(".")
+	// 2. Check if repo is dirty; the snapshot includes the uncommitted changes
+	snap := snapshot(ctx)
+	proceed, err := resolveDirtyTree(ctx)
 	if err != nil {
 		return err
 	}
-	if dirty {
-		// Run prompt for stash/commit/discard
-		pm := tea.NewProgram(newPromptModel())
-		final, err := pm.Run()
-		if err != nil {
-			return err
-		}
-		if p, ok := final.(promptModel); ok {
-			switch p.choice {
-			case choiceStash:
-				_, err = git.RunCombined(context.Background(), ".", "stash", "push", "-u")
-			case choiceCommit:
-				_, err = git.RunCombined(context.Background(), ".", "add", "-A")
-				if err == nil {
-					// Open Git editor for commit message
-					_, err = git.RunCombined(context.Background(), ".", "commit")
-				}
-			case choiceDiscard:
-				_, err = git.RunCombined(context.Background(), ".", "reset", "--hard")
-			case choiceQuit:
-				return nil
-			}
-			if err != nil {
-				return err
-			}
-		}
+	if !proceed {
+		return nil
 	}
 
 	// 3. Run main start model with live logs
-	p := tea.NewProgram(newStartModel(featureName))
-	go runStart(p, featureName) // start git orchestration in background
-	_, err = p.Run()
-	return err
+	data := map[string]string{"branch": "feature/" + sanitizeBranchName(featureName)}
+	if issue != nil {
+		data["issue"] = issue.Key
+	}
+	output.data(data)
+
+	w := startWorkflow(cfg.Trunk, featureName, issue)
+	final, err := runProgram(ctx, newStartModel(ctx, w, featureName), func(ctx context.Context, p sender) {
+		runWorkflow(ctx, p, w, nil)
+	})
+	if err != nil {
+		return rollbackAfter(ctx, snap, final, err)
+	}
+	if m, ok := final.(startModel); ok {
+		return rollbackAfter(ctx, snap, m, finished(m.err))
+	}
+	return nil
 }
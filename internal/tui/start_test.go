@@ -0,0 +1,29 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package tui
+
+import "testing"
+
+func TestIssueSlug(t *testing.T) {
+	tests := []struct {
+		key, title, want string
+	}{
+		{"PROJ-123", "Add login form", "proj-123-add-login-form"},
+		{"#45", "Fix crash on start", "45-fix-crash-on-start"},
+		// without a title the branch is named after the key alone
+		{"#45", "", "45"},
+		{"PROJ-123", "", "proj-123"},
+		{"#7", "Don't log tokens (again)!", "7-don-t-log-tokens-again"},
+		{"#7", "Ünïcode & émojis 🎉", "7-n-code-mojis"},
+		{
+			"PROJ-123", "Make the settings page load faster on slow connections",
+			"proj-123-make-the-settings-page-load-faster-on",
+		},
+	}
+	for _, tt := range tests {
+		if got := issueSlug(tt.key, tt.title); got != tt.want {
+			t.Errorf("issueSlug(%q, %q) = %q, want %q", tt.key, tt.title, got, tt.want)
+		}
+	}
+}
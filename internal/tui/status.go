@@ -0,0 +1,223 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/config"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/git"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/hosting"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ---------------- Snapshot ----------------
+
+type repoStatus struct {
+	Branch        string `json:"branch"`
+	Head          string `json:"head"`
+	Trunk         string `json:"trunk"`
+	Ahead         int    `json:"ahead"`
+	Behind        int    `json:"behind"`
+	Upstream      string `json:"upstream,omitempty"`
+	Unpushed      int    `json:"unpushed"`
+	Dirty         int    `json:"dirty"`
+	LastCommit    string `json:"lastCommit"`
+	LastCommitAge string `json:"lastCommitAge"`
+}
+
+// loadRepoStatus gathers the local branch state shown on the dashboard.
+func loadRepoStatus(ctx context.Context, dir string, cfg config.Config) (repoStatus, error) {
+	var st repoStatus
+	var err error
+	if st.Branch, err = git.CurrentBranch(ctx, dir); err != nil {
+		return st, err
+	}
+	if st.Head, err = git.RevParse(ctx, dir, "HEAD"); err != nil {
+		return st, err
+	}
+	st.Trunk = git.TrunkRef(ctx, dir, cfg.Trunk)
+	st.Ahead, st.Behind, _ = git.AheadBehind(ctx, dir, st.Trunk, "HEAD")
+	if up, err := git.Upstream(ctx, dir); err == nil {
+		st.Upstream = up
+		st.Unpushed, _, _ = git.AheadBehind(ctx, dir, up, "HEAD")
+	}
+	if files, err := git.GitStatusPorcelain(ctx, dir); err == nil {
+		st.Dirty = len(files)
+	}
+	if out, err := git.RunCombined(ctx, dir, "log", "-1", "--format=%s%x00%ct"); err == nil {
+		subject, ts, _ := strings.Cut(out, "\x00")
+		st.LastCommit = subject
+		if unix, err := strconv.ParseInt(ts, 10, 64); err == nil {
+			st.LastCommitAge = humanizeAge(time.Unix(unix, 0))
+		}
+	}
+	return st, nil
+}
+
+// ---------------- Status Model ----------------
+
+type statusModel struct {
+	ctx      context.Context
+	spinner  spinner.Model
+	status   repoStatus
+	provider hosting.Provider // nil when the remote is not a known hosting service
+	checks   []hosting.Check
+	loaded   bool
+	checkErr error
+	help     helpOverlay
+}
+
+func newStatusModel(ctx context.Context, st repoStatus, provider hosting.Provider) statusModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	return statusModel{ctx: ctx, spinner: s, status: st, provider: provider}
+}
+
+func (m statusModel) Init() tea.Cmd {
+	if m.provider == nil {
+		return nil
+	}
+	return tea.Batch(m.spinner.Tick, fetchChecks(m.ctx, m.provider, m.status.Head, 0))
+}
+
+func (m statusModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		var handled bool
+		if m.help, handled = m.help.update(msg); handled {
+			return m, nil
+		}
+		switch {
+		case key.Matches(msg, keys.Quit, interrupt):
+			return m, tea.Quit
+		case key.Matches(msg, keys.Refresh):
+			if m.provider != nil {
+				m.loaded = false
+				return m, tea.Batch(m.spinner.Tick, fetchChecks(m.ctx, m.provider, m.status.Head, 0))
+			}
+		}
+	case spinner.TickMsg:
+		if !m.loaded {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	case checksMsg:
+		m.loaded = true
+		m.checks, m.checkErr = msg.checks, msg.err
+	}
+	return m, nil
+}
+
+func (m statusModel) View() string {
+	head := lipgloss.NewStyle().Bold(true)
+	panel := lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1)
+	st := m.status
+
+	repo := head.Render("Branch") + "\n"
+	repo += fmt.Sprintf("%s @ %s\n", st.Branch, shortHash(st.Head))
+	repo += fmt.Sprintf("vs %s: ↑%d ↓%d\n", st.Trunk, st.Ahead, st.Behind)
+	if st.Upstream != "" {
+		repo += fmt.Sprintf("upstream %s: %d unpushed\n", st.Upstream, st.Unpushed)
+	} else {
+		repo += "no upstream\n"
+	}
+	repo += fmt.Sprintf("%d uncommitted files\n", st.Dirty)
+	repo += fmt.Sprintf("last: %s (%s)", st.LastCommit, st.LastCommitAge)
+
+	ci := head.Render("CI") + "\n"
+	switch {
+	case m.provider == nil:
+		ci += "remote is not a known hosting service"
+	case !m.loaded:
+		ci += m.spinner.View() + " loading checks..."
+	case m.checkErr != nil:
+		ci += "Error: " + m.checkErr.Error()
+	default:
+		ci += renderChecks(m.checks)
+	}
+
+	s := "GitMate: Status\n\n"
+	if m.help.open {
+		return s + m.help.View(m.keys())
+	}
+	s += lipgloss.JoinHorizontal(lipgloss.Top, panel.Render(repo), " ", panel.Render(ci))
+	s += "\n\n" + shortHelp(m.keys()...)
+	return s
+}
+
+func (m statusModel) keys() []key.Binding {
+	refresh := keys.Refresh
+	refresh.SetHelp(refresh.Help().Key, "refresh checks")
+	refresh.SetEnabled(refresh.Enabled() && m.provider != nil)
+	return []key.Binding{refresh, keys.Quit, keys.Help}
+}
+
+// ---------------- Public Entry ----------------
+
+func RunStatusTUI(ctx context.Context) error {
+	cfg, err := config.Load(ctx, ".")
+	if err != nil {
+		return err
+	}
+	st, err := loadRepoStatus(ctx, ".", cfg)
+	if err != nil {
+		return err
+	}
+	// the dashboard still works offline or for unknown hosts, just without CI
+	provider, _ := currentProvider(ctx, cfg)
+
+	if mode.Headless {
+		printStatus(ctx, st, provider)
+		return nil
+	}
+	_, err = runProgram(ctx, newStatusModel(ctx, st, provider), nil)
+	return err
+}
+
+// printStatus writes the dashboard as plain lines, waiting for the checks. With
+// --output json the status becomes the result data instead.
+func printStatus(ctx context.Context, st repoStatus, provider hosting.Provider) {
+	if jsonOutput() {
+		data := struct {
+			repoStatus
+			Checks []checkResult `json:"checks,omitempty"`
+		}{repoStatus: st}
+		if provider != nil {
+			if msg := fetchChecks(ctx, provider, st.Head, 0)().(checksMsg); msg.err != nil {
+				output.warn("checks: " + msg.err.Error())
+			} else {
+				data.Checks = checkResults(msg.checks)
+			}
+		}
+		output.data(data)
+		return
+	}
+	fmt.Printf("branch: %s @ %s\n", st.Branch, shortHash(st.Head))
+	fmt.Printf("vs %s: ahead %d, behind %d\n", st.Trunk, st.Ahead, st.Behind)
+	if st.Upstream != "" {
+		fmt.Printf("upstream %s: %d unpushed\n", st.Upstream, st.Unpushed)
+	} else {
+		fmt.Println("no upstream")
+	}
+	fmt.Printf("%d uncommitted files\n", st.Dirty)
+	fmt.Printf("last: %s (%s)\n", st.LastCommit, st.LastCommitAge)
+	if provider == nil {
+		return
+	}
+	msg := fetchChecks(ctx, provider, st.Head, 0)().(checksMsg)
+	if msg.err != nil {
+		fmt.Println("checks: " + msg.err.Error())
+		return
+	}
+	fmt.Print(renderChecks(msg.checks))
+}
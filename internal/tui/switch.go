@@ -0,0 +1,284 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/failure"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/git"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/workflow"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ---------------- Branch Item ----------------
+
+type branchItem struct {
+	branch   git.Branch
+	ahead    int
+	behind   int
+	conflict []string // dirty paths that also differ on the target branch
+}
+
+func (i branchItem) FilterValue() string { return i.branch.Name }
+
+func (i branchItem) Title() string {
+	title := i.branch.Name
+	if i.branch.Remote {
+		title += " (remote)"
+	}
+	if len(i.conflict) > 0 {
+		title += " ⚠"
+	}
+	return title
+}
+
+func (i branchItem) Description() string {
+	desc := fmt.Sprintf("%s · %s · ↑%d ↓%d", humanizeAge(i.branch.LastCommit), i.branch.Author, i.ahead, i.behind)
+	if len(i.conflict) > 0 {
+		desc += fmt.Sprintf(" · %d dirty files at risk", len(i.conflict))
+	}
+	return desc
+}
+
+// localName is the branch name to check out; remote branches drop their remote prefix.
+func (i branchItem) localName() string {
+	if i.branch.Remote {
+		_, name := git.SplitRemoteRef(i.branch.Name)
+		return name
+	}
+	return i.branch.Name
+}
+
+// loadBranchItems lists local branches plus remote branches that have no local
+// counterpart, annotated with ahead/behind counts against trunk and conflict risk.
+func loadBranchItems(ctx context.Context, dir string) ([]list.Item, error) {
+	local, err := git.ListBranches(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+	remote, err := git.ListRemoteBranches(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+	current, _ := git.CurrentBranch(ctx, dir)
+	trunkRef := git.TrunkRef(ctx, dir, git.DefaultBranch(ctx, dir))
+
+	var dirty []string
+	if status, err := git.GitStatusPorcelain(ctx, dir); err == nil {
+		for _, fs := range status {
+			dirty = append(dirty, fs.Path)
+		}
+	}
+
+	known := map[string]bool{}
+	branches := []git.Branch{}
+	for _, b := range local {
+		known[b.Name] = true
+		if b.Name != current {
+			branches = append(branches, b)
+		}
+	}
+	for _, b := range remote {
+		_, name := git.SplitRemoteRef(b.Name)
+		if known[name] {
+			continue
+		}
+		known[name] = true
+		branches = append(branches, b)
+	}
+
+	items := make([]list.Item, 0, len(branches))
+	for _, b := range branches {
+		item := branchItem{branch: b}
+		item.ahead, item.behind, _ = git.AheadBehind(ctx, dir, trunkRef, b.Hash)
+		if len(dirty) > 0 {
+			item.conflict = conflictRisk(ctx, dir, b.Hash, dirty)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// conflictRisk returns the dirty paths that differ between HEAD and target, i.e. the
+// files git would refuse to carry over (or would have to merge) when switching.
+func conflictRisk(ctx context.Context, dir, target string, dirty []string) []string {
+	changed, err := git.DiffNames(ctx, dir, "HEAD", target)
+	if err != nil {
+		return nil
+	}
+	set := map[string]bool{}
+	for _, p := range changed {
+		set[p] = true
+	}
+	var res []string
+	for _, p := range dirty {
+		if set[p] {
+			res = append(res, p)
+		}
+	}
+	return res
+}
+
+// ---------------- Picker Model ----------------
+
+type switchPickerModel struct {
+	list   list.Model
+	done   bool
+	choice *branchItem
+}
+
+func newSwitchPickerModel(items []list.Item) switchPickerModel {
+	l := newList(items, "Switch to branch", 80, 20)
+	return switchPickerModel{list: l}
+}
+
+func (m switchPickerModel) Init() tea.Cmd { return nil }
+
+func (m switchPickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.list.SetSize(msg.Width, msg.Height-1)
+	case tea.KeyMsg:
+		// let the filter input consume keys while the user is typing
+		if m.list.FilterState() == list.Filtering {
+			break
+		}
+		switch {
+		case key.Matches(msg, keys.Select):
+			if i, ok := m.list.SelectedItem().(branchItem); ok {
+				m.choice = &i
+				m.done = true
+				return m, tea.Quit
+			}
+		case key.Matches(msg, keys.Quit, interrupt):
+			m.done = true
+			return m, tea.Quit
+		}
+	}
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m switchPickerModel) View() string {
+	if m.done {
+		return ""
+	}
+	return m.list.View()
+}
+
+// ---------------- Switch Model ----------------
+
+type switchModel struct {
+	runModel
+	branch string
+}
+
+func newSwitchModel(ctx context.Context, branch string) switchModel {
+	m := switchModel{runModel: newRunModel(ctx, fmt.Sprintf("Switching to '%s'", branch), workflow.Workflow{}, nil), branch: branch}
+	m.quitOnDone = true
+	return m
+}
+
+func (m switchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.runModel, cmd = m.update(msg)
+	return m, cmd
+}
+
+func (m switchModel) View() string {
+	return m.view("Running git switch...", fmt.Sprintf("Now on %s.", m.branch))
+}
+
+// ---------------- Orchestration ----------------
+
+// runSwitch checks out the chosen branch, creating a tracking branch for remote-only refs.
+func runSwitch(ctx context.Context, p sender, item branchItem) {
+	args := []string{"switch", item.branch.Name}
+	if item.branch.Remote {
+		args = []string{"switch", "--track", item.branch.Name}
+	}
+	runWorkflow(ctx, p, workflow.Workflow{Name: "switch", Steps: []workflow.Step{
+		{Name: "Switch to " + item.localName(), Git: args},
+	}}, nil)
+}
+
+// ---------------- Public Entry ----------------
+
+// RunSwitchTUI switches to branch, or lets the user pick one when branch is empty.
+func RunSwitchTUI(ctx context.Context, branch string) error {
+	return runApp(ctx, func(ctx context.Context) error { return switchFlow(ctx, branch) })
+}
+
+// switchFlow picks the branch, deals with uncommitted changes and switches.
+func switchFlow(ctx context.Context, branch string) error {
+	items, err := loadBranchItems(ctx, ".")
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		say("No other branches to switch to.\n")
+		return nil
+	}
+
+	choice, err := pickBranch(ctx, items, branch)
+	if err != nil || choice == nil {
+		return err
+	}
+	if len(choice.conflict) > 0 {
+		say("Uncommitted changes in %s also differ on %s.\n",
+			strings.Join(choice.conflict, ", "), choice.branch.Name)
+	}
+
+	proceed, err := resolveDirtyTree(ctx)
+	if err != nil {
+		return err
+	}
+	if !proceed {
+		return nil
+	}
+
+	final, err := runProgram(ctx, newSwitchModel(ctx, choice.localName()), func(ctx context.Context, p sender) { runSwitch(ctx, p, *choice) })
+	if err != nil {
+		return err
+	}
+	if m, ok := final.(switchModel); ok {
+		return finished(m.err)
+	}
+	return nil
+}
+
+// pickBranch finds the named branch among items (local or remote name), or opens
+// the picker when no name was given.
+func pickBranch(ctx context.Context, items []list.Item, name string) (*branchItem, error) {
+	if cur, _ := git.CurrentBranch(ctx, "."); name != "" && cur == name {
+		say("Already on %s.\n", name)
+		return nil, nil
+	}
+	if name != "" {
+		for _, it := range items {
+			if b := it.(branchItem); b.branch.Name == name || b.localName() == name {
+				return &b, nil
+			}
+		}
+		return nil, failure.Errorf(failure.Usage, "no branch named %s", name)
+	}
+	if mode.Headless {
+		return nil, needsAnswer("the branch to switch to", "it as an argument")
+	}
+	final, err := show(fullScreen{newSwitchPickerModel(items)})
+	if err != nil {
+		return nil, err
+	}
+	m, ok := final.(switchPickerModel)
+	if !ok {
+		return nil, nil
+	}
+	return m.choice, nil
+}
@@ -4,103 +4,81 @@ Copyright © 2025 Bernard Katamanso
 package tui
 
 import (
-	"github.com/charmbracelet/bubbles/spinner"
+	"context"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/config"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/git"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/workflow"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 type SyncModel struct {
-	spinner spinner.Model
-	logs    []string
-	err     error
-	done    bool
+	runModel
 }
 
 // NewSyncModel Creates a new syncModel
-func NewSyncModel() SyncModel {
-	s := spinner.New()
-	s.Spinner = spinner.Dot
-	return SyncModel{
-		spinner: s,
-		logs:    []string{},
-	}
-}
-
-func (m SyncModel) Init() tea.Cmd {
-	return m.spinner.Tick
+func NewSyncModel(ctx context.Context, trunkRef string) SyncModel {
+	return SyncModel{newRunModel(ctx, "Syncing with "+trunkRef, syncWorkflow(trunkRef), nil)}
 }
 
 func (m SyncModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		if msg.String() == "q" || msg.String() == "ctrl+c" {
-			return m, tea.Quit
-		}
-
-	case spinner.TickMsg:
-		if !m.done {
-			var cmd tea.Cmd
-			m.spinner, cmd = m.spinner.Update(msg)
-			return m, cmd
-		}
+	var cmd tea.Cmd
+	m.runModel, cmd = m.update(msg)
+	return m, cmd
+}
 
-	case gitLineMsg:
-		m.logs = append(m.logs, string(msg))
-		return m, nil
+func (m SyncModel) View() string {
+	return m.view("Running git fetch & rebase...", "Sync complete.")
+}
 
-	case gitErrMsg:
-		m.err = msg
-		m.done = true
-		return m, nil
+// --- Orchestration of sync steps
 
-	case gitDoneMsg:
-		m.done = true
-		return m, nil
-	}
+// syncWorkflow fetches every remote and rebases the current branch onto
+// trunkRef, e.g. origin/main.
+func syncWorkflow(trunkRef string) workflow.Workflow {
+	return workflow.Workflow{Name: "sync", Steps: []workflow.Step{
+		// before the fetch: the lease must not include commits teammates pushed
+		// since, which the rebase onto trunk doesn't bring in
+		leaseStep,
+		{
+			Name:    "Fetch",
+			Explain: "Download the new commits of every remote.",
+			Git:     []string{"fetch", "--all", "--progress"},
+			Retries: 2,
+		},
+		{
+			Name:    "Rebase onto " + trunkRef,
+			Explain: "Replay your commits on top of the latest trunk.",
+			Git:     []string{"rebase", trunkRef},
+		},
+	}}
+}
 
-	return m, nil
+func RunSyncTUI(ctx context.Context) error {
+	return runApp(ctx, syncFlow)
 }
 
-func (m SyncModel) View() string {
-	s := "GitMate: Syncing with main\n\n"
-	if m.err != nil {
-		s += "Error: " + m.err.Error() + "\n"
-	} else if m.done {
-		s += "✅ Sync complete.\n\n"
-	} else {
-		s += m.spinner.View() + " Running git fetch & rebase...\n\n"
+// syncFlow rebases onto trunk, offering to roll back when that fails.
+func syncFlow(ctx context.Context) error {
+	cfg, err := config.Load(ctx, ".")
+	if err != nil {
+		return err
 	}
-
-	// show last 10 log lines
-	start := 0
-	if len(m.logs) > 10 {
-		start = len(m.logs) - 10
+	trunkRef := git.TrunkRef(ctx, ".", cfg.Trunk)
+	snap := snapshot(ctx)
+	final, err := runProgram(ctx, NewSyncModel(ctx, trunkRef), func(ctx context.Context, p sender) {
+		runWorkflow(ctx, p, syncWorkflow(trunkRef), nil)
+	})
+	if err != nil {
+		return rollbackAfter(ctx, snap, final, err)
 	}
-	for _, line := range m.logs[start:] {
-		s += line + "\n"
+	m, ok := final.(SyncModel)
+	if !ok || !m.done {
+		return nil
 	}
-
-	if m.done {
-		s += "\n(press q to quit)"
+	if m.err != nil {
+		return rollbackAfter(ctx, snap, m, finished(m.err))
 	}
-	return s
-}
-
-// --- Orchestration of sync steps
-func runSync(p *tea.Program) {
-	// Step 1: git fetch --all
-	streamStep(p, "fetch", []string{"--all"}, func() {
-		// Step 2: git rebase origin/main
-		streamStep(p, "rebase", []string{"origin/main"}, func() {
-			// Step 3: done
-			p.Send(gitDoneMsg{})
-		})
-	})
-}
-
-func RunSyncTUI() error {
-	p := tea.NewProgram(NewSyncModel())
-	// start orchestration after the program begins
-	go runSync(p)
-	_, err := p.Run()
-	return err
+	printCoachSummary(ctx, ".")
+	return nil
 }
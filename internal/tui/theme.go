@@ -0,0 +1,138 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package tui
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/config"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/failure"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// ---------------- Themes ----------------
+
+// Theme holds the colors of the TUI. Colors are hex ("#6f03fc") or ANSI
+// numbers ("241"); an empty color leaves the terminal's default.
+type Theme struct {
+	Accent  lipgloss.Color // selections, commands and progress bars
+	Muted   lipgloss.Color // secondary text
+	Success lipgloss.Color // passed checks and finished steps
+	Warning lipgloss.Color // stderr output
+	Error   lipgloss.Color // errors and failed checks
+}
+
+// Themes are the builtin themes. auto is GitMate's original look; apart from
+// the accent it uses the terminal's own ANSI palette.
+var Themes = map[string]Theme{
+	"auto":          {Accent: "#6f03fc", Muted: "241", Success: "2", Warning: "3", Error: "1"},
+	"dark":          {Accent: "#a56eff", Muted: "245", Success: "#5fd787", Warning: "#ffd75f", Error: "#ff5f5f"},
+	"light":         {Accent: "#5a02cc", Muted: "243", Success: "#008700", Warning: "#af5f00", Error: "#d70000"},
+	"high-contrast": {Accent: "14", Muted: "15", Success: "10", Warning: "11", Error: "9"},
+}
+
+// theme is the theme in use, chosen by SetTheme.
+var theme = Themes["auto"]
+
+// noColor reports whether colors are off because NO_COLOR is set
+// (https://no-color.org).
+func noColor() bool { return os.Getenv("NO_COLOR") != "" }
+
+// SetTheme selects the theme configured under ui: in the config. With NO_COLOR
+// set, colors are turned off instead. An unknown theme is a usage error.
+func SetTheme(cfg config.UIConfig) error {
+	if noColor() {
+		theme = Theme{}
+		lipgloss.SetColorProfile(termenv.Ascii)
+		return nil
+	}
+	t, err := resolveTheme(cfg, cfg.Theme, nil)
+	if err != nil {
+		return err
+	}
+	theme = t
+	return nil
+}
+
+// resolveTheme looks up name among the user themes and then the builtin ones.
+// A user theme starts from its base; seen holds the user themes being resolved,
+// so a theme can restyle the builtin theme of the same name without looping.
+func resolveTheme(cfg config.UIConfig, name string, seen []string) (Theme, error) {
+	if name == "" {
+		name = "auto"
+	}
+	if custom, ok := cfg.Themes[name]; ok && !slices.Contains(seen, name) {
+		base, err := resolveTheme(cfg, custom.Base, append(seen, name))
+		if err != nil {
+			return Theme{}, fmt.Errorf("theme %s: %w", name, err)
+		}
+		return overlay(base, custom), nil
+	}
+	if t, ok := Themes[name]; ok {
+		return t, nil
+	}
+	if slices.Contains(seen, name) {
+		return Theme{}, failure.Errorf(failure.Usage, "themes based on each other in a loop: %s", strings.Join(append(seen, name), " → "))
+	}
+	names := []string{"auto", "dark", "light", "high-contrast"}
+	for n := range cfg.Themes {
+		if _, ok := Themes[n]; !ok {
+			names = append(names, n)
+		}
+	}
+	slices.Sort(names[4:])
+	return Theme{}, failure.Errorf(failure.Usage, "unknown theme %q: want %s", name, strings.Join(names, ", "))
+}
+
+// overlay sets the colors of t that c sets.
+func overlay(t Theme, c config.ThemeConfig) Theme {
+	for _, f := range []struct {
+		dst *lipgloss.Color
+		src string
+	}{
+		{&t.Accent, c.Accent},
+		{&t.Muted, c.Muted},
+		{&t.Success, c.Success},
+		{&t.Warning, c.Warning},
+		{&t.Error, c.Error},
+	} {
+		if f.src != "" {
+			*f.dst = lipgloss.Color(f.src)
+		}
+	}
+	return t
+}
+
+// ---------------- Styles ----------------
+
+func (t Theme) accent() lipgloss.Style { return lipgloss.NewStyle().Foreground(t.Accent) }
+func (t Theme) muted() lipgloss.Style  { return lipgloss.NewStyle().Foreground(t.Muted) }
+func (t Theme) good() lipgloss.Style   { return lipgloss.NewStyle().Foreground(t.Success) }
+func (t Theme) warn() lipgloss.Style   { return lipgloss.NewStyle().Foreground(t.Warning) }
+func (t Theme) bad() lipgloss.Style    { return lipgloss.NewStyle().Foreground(t.Error) }
+
+// listDelegate is the list item delegate with the selection in the accent color.
+func (t Theme) listDelegate() list.DefaultDelegate {
+	d := list.NewDefaultDelegate()
+	d.Styles.SelectedTitle = d.Styles.SelectedTitle.Foreground(t.Accent).BorderLeftForeground(t.Accent)
+	d.Styles.SelectedDesc = d.Styles.SelectedTitle
+	return d
+}
+
+// progressBar is a progress bar filled with the accent color.
+func (t Theme) progressBar() progress.Model {
+	opts := []progress.Option{progress.WithoutPercentage()}
+	if t.Accent == "" {
+		opts = append(opts, progress.WithColorProfile(termenv.Ascii))
+	} else {
+		opts = append(opts, progress.WithSolidFill(string(t.Accent)))
+	}
+	return progress.New(opts...)
+}
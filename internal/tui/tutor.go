@@ -4,111 +4,283 @@ Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
 package tui
 
 import (
+	"context"
 	"fmt"
-	"github.com/Orctatech-Engineering-Team/GitMate/internal/git"
+	"strings"
+	"time"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/config"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/failure"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/lesson"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/sandbox"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
-// ---------------- Tutor Step ----------------
+// TutorOptions configures `gitmate tutor`.
+type TutorOptions struct {
+	Keep bool // keep the practice repository after the lesson
+}
+
+// verifyInterval is how often the current step's checks are re-run while the
+// learner works in another terminal.
+const verifyInterval = time.Second
+
+// ---------------- Lesson Picker ----------------
+
+type lessonItem struct {
+	lesson   lesson.Lesson
+	progress *lesson.LessonProgress // nil if never started
+}
+
+func (i lessonItem) FilterValue() string { return i.lesson.ID + " " + i.lesson.Title }
+func (i lessonItem) Title() string       { return i.lesson.Title }
+
+func (i lessonItem) Description() string {
+	desc := i.lesson.ID + " · " + countNoun(len(i.lesson.Steps), "step")
+	if i.lesson.Level != "" {
+		desc += " · " + i.lesson.Level
+	}
+	switch lp := i.progress; {
+	case lp == nil:
+	case lp.InProgress():
+		desc += fmt.Sprintf(" · ◐ %d/%d done", lp.StepsDone(), len(i.lesson.Steps))
+	case lp.Completed:
+		desc += " · ✅"
+	}
+	if i.lesson.Summary != "" {
+		desc += " · " + i.lesson.Summary
+	}
+	return desc
+}
+
+type tutorPickerModel struct {
+	list   list.Model
+	done   bool
+	choice *lesson.Lesson
+}
+
+// newTutorPickerModel lists lessons with their progress and preselects the one the
+// learner was last working on.
+func newTutorPickerModel(lessons []lesson.Lesson, progress *lesson.Progress) tutorPickerModel {
+	items := make([]list.Item, 0, len(lessons))
+	resume := 0
+	last := progress.LastInProgress()
+	for i, l := range lessons {
+		items = append(items, lessonItem{lesson: l, progress: progress.Lessons[l.ID]})
+		if l.ID == last {
+			resume = i
+		}
+	}
+	l := newList(items, "Choose a lesson", 80, 20)
+	l.Select(resume)
+	return tutorPickerModel{list: l}
+}
+
+func (m tutorPickerModel) Init() tea.Cmd { return nil }
+
+func (m tutorPickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.list.SetSize(msg.Width, msg.Height-1)
+	case tea.KeyMsg:
+		if m.list.FilterState() == list.Filtering {
+			break
+		}
+		switch {
+		case key.Matches(msg, keys.Select):
+			if i, ok := m.list.SelectedItem().(lessonItem); ok {
+				m.choice = &i.lesson
+				m.done = true
+				return m, tea.Quit
+			}
+		case key.Matches(msg, keys.Quit, interrupt):
+			m.done = true
+			return m, tea.Quit
+		}
+	}
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m tutorPickerModel) View() string {
+	if m.done {
+		return ""
+	}
+	return m.list.View()
+}
+
+// ---------------- Verification ----------------
+
+type verifyMsg struct {
+	step      int
+	ok        bool
+	detail    string
+	requested bool // the learner asked for the check (challenges), rather than a poll
+}
 
-type tutorStep struct {
-	Title       string
-	Description string
-	Command     string
-	Action      func(*tea.Program) // optional live execution
-	Completed   bool
+// verifyStep evaluates step idx's checks after delay.
+func verifyStep(ctx context.Context, v lesson.Verifier, step lesson.Step, idx int, delay time.Duration, requested bool) tea.Cmd {
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		ok, detail := v.Step(ctx, step)
+		return verifyMsg{step: idx, ok: ok, detail: detail, requested: requested}
+	})
 }
 
 // ---------------- Tutor Model ----------------
 
+// score penalties, out of 100
+const (
+	failedCheckPenalty = 10 // per failed challenge check or wrong answer
+	hintPenalty        = 15 // per hint revealed
+)
+
 type tutorModel struct {
+	ctx       context.Context // bounds the checks the model runs
 	spinner   spinner.Model
-	steps     []tutorStep
+	lesson    lesson.Lesson
+	verifier  lesson.Verifier
 	current   int
+	completed []bool
+	hints     []int  // hints revealed per step
+	detail    string // what the current step is still waiting for
+	checking  bool   // a requested challenge check is running
+	failed    int    // failed checks and wrong answers this session
+	hintsUsed int
+	keep      bool // keep the sandbox when the tutor exits
 	logs      []string
+	help      helpOverlay
 	done      bool
-	repoReady bool
 }
 
-// ---------------- Constructor ----------------
-
-func NewTutorModel() tutorModel {
+// newTutorModel starts l with the given steps already completed (when resuming).
+func newTutorModel(ctx context.Context, l lesson.Lesson, v lesson.Verifier, completed []bool, keep bool) tutorModel {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
-	isGitRepo, _ := git.IsRepo(".")
-
-	steps := []tutorStep{
-		{
-			Title:       "Start Command",
-			Description: "Learn how to start a new feature branch with `gitmate start <feature>`.",
-			Command:     "gitmate start login-api",
-			Action: func(p *tea.Program) {
-				if isGitRepo {
-					_ = RunStartTUI("login-api")
-				} else {
-					p.Send(tutorMsg("Repository not initialized. Cannot run start command."))
-				}
-			},
-		},
-		{
-			Title:       "Sync Command",
-			Description: "Keep your branch up-to-date with main using `gitmate sync`.",
-			Command:     "gitmate sync",
-			Action: func(p *tea.Program) {
-				if isGitRepo {
-					_ = RunSyncTUI()
-				} else {
-					p.Send(tutorMsg("Repository not initialized. Cannot run sync command."))
-				}
-			},
-		},
+	m := tutorModel{
+		ctx:       ctx,
+		spinner:   s,
+		lesson:    l,
+		verifier:  v,
+		keep:      keep,
+		completed: append([]bool{}, completed...),
+		hints:     make([]int, len(l.Steps)),
 	}
+	m.current = m.nextStep()
+	return m
+}
 
-	repoReady := isGitRepo
-	return tutorModel{
-		spinner:   s,
-		steps:     steps,
-		current:   0,
-		logs:      []string{},
-		repoReady: repoReady,
+func (m tutorModel) Init() tea.Cmd {
+	if m.lesson.Challenge() {
+		return m.spinner.Tick
 	}
+	return tea.Batch(m.spinner.Tick, m.verifyNext(0))
 }
 
-// ---------------- Init ----------------
+// nextStep is the first unfinished step. Steps complete in order: later checks
+// often hold trivially before the earlier steps have been done.
+func (m tutorModel) nextStep() int {
+	for i, done := range m.completed {
+		if !done {
+			return i
+		}
+	}
+	return len(m.completed) - 1
+}
 
-func (m tutorModel) Init() tea.Cmd {
-	return m.spinner.Tick
+func (m tutorModel) verifyNext(delay time.Duration) tea.Cmd {
+	i := m.nextStep()
+	return verifyStep(m.ctx, m.verifier, m.lesson.Steps[i], i, delay, false)
+}
+
+// score rates the session out of 100.
+func (m tutorModel) score() int {
+	return max(0, 100-failedCheckPenalty*m.failed-hintPenalty*m.hintsUsed)
+}
+
+// complete marks step i done and moves on to the next unfinished step.
+func (m tutorModel) complete(i int) (tutorModel, tea.Cmd) {
+	m.completed[i] = true
+	m.detail = ""
+	m.logs = append(m.logs, fmt.Sprintf("✅ Step %d complete: %s", i+1, m.lesson.Steps[i].Title))
+	if next := m.nextStep(); !m.completed[next] {
+		m.current = next
+		return m, nil
+	}
+	m.done = true
+	done := "🎉 Lesson complete: " + m.lesson.Title
+	if m.lesson.Challenge() {
+		done += fmt.Sprintf(" — score %d/100", m.score())
+	}
+	m.logs = append(m.logs, done)
+	return m, tea.Quit
 }
 
-// ---------------- Update ----------------
+// answer grades choice (1-based) for the current question step.
+func (m tutorModel) answer(choice int) (tutorModel, tea.Cmd) {
+	q := m.lesson.Steps[m.current].Question
+	if choice < 1 || choice > len(q.Choices) {
+		return m, nil
+	}
+	if choice != q.Answer {
+		m.failed++
+		m.logs = append(m.logs, fmt.Sprintf("❌ %q isn't it. Try again.", q.Choices[choice-1]))
+		return m, nil
+	}
+	if q.Explain != "" {
+		m.logs = append(m.logs, "💡 "+m.verifier.Expand(q.Explain))
+	}
+	return m.complete(m.current)
+}
 
 func (m tutorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
-
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "ctrl+c":
+		step := m.lesson.Steps[m.current]
+		actionable := !m.done && m.current == m.nextStep() && !m.completed[m.current]
+		var handled bool
+		if m.help, handled = m.help.update(msg); handled {
+			return m, nil
+		}
+		switch {
+		case key.Matches(msg, keys.Quit, interrupt):
 			return m, tea.Quit
-		case "n": // next
-			if m.current < len(m.steps)-1 {
+		case key.Matches(msg, keys.TutorKeep):
+			m.keep = !m.keep
+		case key.Matches(msg, keys.TutorNext):
+			if m.current < len(m.lesson.Steps)-1 {
 				m.current++
+				m.detail = ""
 			}
-		case "p": // previous
+		case key.Matches(msg, keys.TutorPrev):
 			if m.current > 0 {
 				m.current--
+				m.detail = ""
 			}
-		case "r": // run current step
-			step := m.steps[m.current]
-			if step.Action != nil {
-				go step.Action(nil) // run in goroutine
-				m.logs = append(m.logs, fmt.Sprintf("Running step: %s", step.Title))
-			} else {
-				m.logs = append(m.logs, "Step cannot be run directly.")
+		case key.Matches(msg, keys.TutorHint):
+			if actionable && m.hints[m.current] < len(step.Hints) {
+				m.hints[m.current]++
+				m.hintsUsed++
+			}
+		case key.Matches(msg, keys.TutorCheck):
+			// challenges are checked on request so that attempts can be scored
+			if actionable && m.lesson.Challenge() && len(step.Verify) > 0 && !m.checking {
+				m.checking = true
+				return m, verifyStep(m.ctx, m.verifier, step, m.current, 0, true)
+			}
+		case key.Matches(msg, keys.Select):
+			// steps without checks are read-and-try; the learner says when they're done
+			if actionable && step.Manual() {
+				return m.complete(m.current)
+			}
+		case key.Matches(msg, answerKeys):
+			if actionable && step.Question != nil {
+				return m.answer(int(msg.String()[0] - '0'))
 			}
-		case "c": // mark completed
-			m.steps[m.current].Completed = true
-			m.logs = append(m.logs, fmt.Sprintf("Step marked completed: %s", m.steps[m.current].Title))
 		}
 
 	case spinner.TickMsg:
@@ -118,35 +290,118 @@ func (m tutorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 
-	case tutorMsg:
-		m.logs = append(m.logs, string(msg))
-	case tutorErrMsg:
-		m.logs = append(m.logs, "Error: "+msg.Error())
-	case tutorDoneMsg:
-		m.done = true
-		return m, tea.Quit
+	case verifyMsg:
+		if m.done {
+			return m, nil
+		}
+		step := m.lesson.Steps[msg.step]
+		fresh := msg.step == m.nextStep() && !m.completed[msg.step]
+		if msg.requested {
+			m.checking = false
+			if !fresh {
+				return m, nil
+			}
+			if msg.ok {
+				return m.complete(msg.step)
+			}
+			m.failed++
+			m.detail = msg.detail
+			m.logs = append(m.logs, "❌ Not yet: "+msg.detail)
+			return m, nil
+		}
+		if fresh && len(step.Verify) > 0 {
+			if msg.ok {
+				var cmd tea.Cmd
+				m, cmd = m.complete(msg.step)
+				if m.done {
+					return m, cmd
+				}
+			} else {
+				m.detail = msg.detail
+			}
+		}
+		return m, m.verifyNext(verifyInterval)
 	}
 
 	return m, nil
 }
 
-// ---------------- View ----------------
-
 func (m tutorModel) View() string {
-	step := m.steps[m.current]
-	s := fmt.Sprintf("Tutor: Step %d/%d\n\n", m.current+1, len(m.steps))
-	s += fmt.Sprintf("Title: %s\n", step.Title)
-	s += fmt.Sprintf("Description:\n%s\n\n", step.Description)
-	if step.Command != "" {
-		s += fmt.Sprintf("Command: %s\n", step.Command)
+	dim := theme.muted()
+	cmdStyle := theme.accent()
+	text := lipgloss.NewStyle().Width(76)
+	expand := m.verifier.Expand
+
+	step := m.lesson.Steps[m.current]
+	s := fmt.Sprintf("Tutor: %s — Step %d/%d\n", m.lesson.Title, m.current+1, len(m.lesson.Steps))
+	if m.help.open {
+		return s + "\n" + m.help.View(m.keys())
 	}
-	if step.Completed {
-		s += "✅ Completed\n\n"
+	for i := range m.lesson.Steps {
+		switch {
+		case m.completed[i]:
+			s += "●"
+		case i == m.current:
+			s += "◉"
+		default:
+			s += "○"
+		}
+	}
+	s += "\n"
+	if m.verifier.Dir != "" {
+		s += dim.Render("Practice repository: cd "+m.verifier.Dir) + "\n"
 	}
+	s += "\n"
 
-	if !m.done {
-		s += m.spinner.View() + " Navigate with (n)ext/(p)revious, (r)un step, (c)omplete, (q)uit\n\n"
+	if m.current == 0 && m.lesson.Intro != "" {
+		s += text.Render(expand(m.lesson.Intro)) + "\n\n"
+	}
+	s += lipgloss.NewStyle().Bold(true).Render(step.Title) + "\n"
+	if step.Explain != "" {
+		s += text.Render(expand(step.Explain)) + "\n"
+	}
+	if q := step.Question; q != nil {
+		s += text.Render(expand(q.Prompt)) + "\n\n"
+		for i, c := range q.Choices {
+			s += fmt.Sprintf("  %d) %s\n", i+1, expand(c))
+		}
+	}
+	if len(step.Commands) > 0 {
+		s += "\nTry:\n"
+		for _, c := range step.Commands {
+			s += cmdStyle.Render("  $ "+expand(c)) + "\n"
+		}
+	}
+	for i := 0; i < m.hints[m.current]; i++ {
+		s += text.Render(fmt.Sprintf("💡 Hint %d: %s", i+1, expand(step.Hints[i]))) + "\n"
+	}
+	s += "\n"
+
+	switch {
+	case m.completed[m.current]:
+		s += "✅ Completed\n"
+	case m.current != m.nextStep():
+		s += fmt.Sprintf("Finish step %d first.\n", m.nextStep()+1)
+	case step.Question != nil:
+		s += "Answer with the number of your choice.\n"
+	case step.Manual():
+		s += "Press enter when you're done.\n"
+	case m.checking:
+		s += m.spinner.View() + " Checking the repository...\n"
+	case m.lesson.Challenge():
+		s += "Press c to check your solution."
+		if m.detail != "" {
+			s += " Last check: " + m.detail
+		}
+		s += "\n"
+	case !m.done:
+		s += m.spinner.View() + " Watching the repository"
+		if m.detail != "" {
+			s += ": " + m.detail
+		}
+		s += "\n"
 	}
+	s += "\n"
 
 	start := 0
 	if len(m.logs) > 10 {
@@ -157,16 +412,199 @@ func (m tutorModel) View() string {
 	}
 
 	if m.done {
-		s += "\n(press q to quit)"
+		s += "\n" + shortHelp(keys.Quit)
+		return s
 	}
-
+	s += shortHelp(m.keys()...)
 	return s
 }
 
+// keys are the bindings that apply to the current step.
+func (m tutorModel) keys() []key.Binding {
+	step := m.lesson.Steps[m.current]
+	bindings := []key.Binding{keys.TutorNext, keys.TutorPrev}
+	if step.Question != nil {
+		bindings = append(bindings, answerKeys)
+	}
+	if step.Manual() {
+		done := keys.Select
+		done.SetHelp(done.Help().Key, "done")
+		bindings = append(bindings, done)
+	}
+	if m.lesson.Challenge() {
+		bindings = append(bindings, keys.TutorCheck)
+	}
+	if m.hints[m.current] < len(step.Hints) {
+		hint := keys.TutorHint
+		hint.SetHelp(hint.Help().Key, fmt.Sprintf("hint %d/%d", m.hints[m.current]+1, len(step.Hints)))
+		bindings = append(bindings, hint)
+	}
+	if m.verifier.Dir != "" {
+		keep := keys.TutorKeep
+		if m.keep {
+			keep.SetHelp(keep.Help().Key, "keep sandbox when finished")
+		} else {
+			keep.SetHelp(keep.Help().Key, "delete sandbox when finished")
+		}
+		bindings = append(bindings, keep)
+	}
+	return append(bindings, keys.Quit, keys.Help)
+}
+
 // ---------------- Public Entry ----------------
 
-func RunTutorTUI() error {
-	p := tea.NewProgram(NewTutorModel())
-	_, err := p.Run()
+// loadLessons returns the builtin lessons plus those in the configured and user
+// lesson directories.
+func loadLessons(cfg config.Config) ([]lesson.Lesson, error) {
+	dirs := append([]string{}, cfg.Tutor.LessonDirs...)
+	if dir, err := lesson.UserDir(); err == nil {
+		dirs = append(dirs, dir)
+	}
+	return lesson.All(dirs...)
+}
+
+// RunTutorTUI runs the lesson with the given id, or lets the learner pick one.
+// Each lesson gets a fresh sandbox repository, so the learner's own repositories
+// are never touched. Steps complete once the sandbox reaches the expected state.
+// Quitting part way keeps the sandbox so the next run resumes where it stopped.
+func RunTutorTUI(ctx context.Context, lessonID string, opts TutorOptions) error {
+	return runApp(ctx, func(context.Context) error { return tutorFlow(ctx, lessonID, opts) })
+}
+
+// tutorFlow picks the lesson, runs it and saves the progress.
+func tutorFlow(ctx context.Context, lessonID string, opts TutorOptions) error {
+	if mode.Headless {
+		return failure.Errorf(failure.Usage, "lessons are interactive; run gitmate tutor in a terminal")
+	}
+	cfg, err := config.Load(ctx, ".")
+	if err != nil {
+		return err
+	}
+	lessons, err := loadLessons(cfg)
+	if err != nil {
+		return err
+	}
+	progress, err := lesson.LoadProgress()
+	if err != nil {
+		return fmt.Errorf("reading tutor progress: %w", err)
+	}
+
+	var chosen lesson.Lesson
+	if lessonID != "" {
+		var ok bool
+		if chosen, ok = lesson.Find(lessons, lessonID); !ok {
+			return fmt.Errorf("no lesson %q; see `gitmate tutor list`", lessonID)
+		}
+	} else {
+		final, err := show(fullScreen{newTutorPickerModel(lessons, progress)})
+		if err != nil {
+			return err
+		}
+		m, ok := final.(tutorPickerModel)
+		if !ok || m.choice == nil {
+			return nil
+		}
+		chosen = *m.choice
+	}
+
+	lp := progress.Lesson(chosen)
+	sb, resumed := resumeSandbox(lp)
+	if !resumed {
+		// a quiz has no repository to lose, so an unfinished one carries on
+		if chosen.NeedsRepo() || !lp.InProgress() {
+			lp.Steps = make([]bool, len(chosen.Steps))
+			lp.Attempts++
+		}
+		if chosen.NeedsRepo() {
+			if sb, err = sandbox.New(ctx); err != nil {
+				return fmt.Errorf("creating practice repository: %w", err)
+			}
+			if err := sb.Apply(ctx, chosen.Setup); err != nil {
+				sb.Remove()
+				return fmt.Errorf("preparing lesson %s: %w", chosen.ID, err)
+			}
+		}
+	}
+	started := time.Now()
+	if lp.StartedAt.IsZero() {
+		lp.StartedAt = started
+	}
+
+	v := lesson.Verifier{Vars: map[string]string{"trunk": sandbox.Trunk}}
+	if sb != nil {
+		v.Dir = sb.Work
+	}
+	final, err := show(newTutorModel(ctx, chosen, v, lp.Steps, opts.Keep))
+
+	finished, keep, score := false, opts.Keep, 0
+	if m, ok := final.(tutorModel); ok {
+		lp.Steps = m.completed
+		lp.HintsUsed += m.hintsUsed
+		finished, keep, score = m.done, m.keep, m.score()
+	}
+	now := time.Now()
+	lp.TimeSpent += int64(now.Sub(started).Seconds())
+	lp.LastActivity = now
+	if finished {
+		lp.Completed = true
+		lp.CompletedAt = &now
+		lp.BestScore = max(lp.BestScore, score)
+	}
+
+	lp.Sandbox = ""
+	switch {
+	case sb == nil:
+	case !finished:
+		lp.Sandbox = sb.Root
+		say("Progress saved. Run `gitmate tutor %s` to pick up in %s\n", chosen.ID, sb.Work)
+	case keep:
+		say("Practice repository kept at %s\n", sb.Work)
+	default:
+		if rmErr := sb.Remove(); rmErr != nil && err == nil {
+			err = rmErr
+		}
+	}
+	if saveErr := progress.Save(); saveErr != nil && err == nil {
+		err = fmt.Errorf("saving tutor progress: %w", saveErr)
+	}
 	return err
 }
+
+// resumeSandbox reopens the sandbox of an unfinished attempt, if it still exists.
+func resumeSandbox(lp *lesson.LessonProgress) (*sandbox.Sandbox, bool) {
+	if !lp.InProgress() || lp.Sandbox == "" {
+		return nil, false
+	}
+	sb, err := sandbox.Open(lp.Sandbox)
+	if err != nil {
+		return nil, false
+	}
+	return sb, true
+}
+
+// ListLessons prints the available lessons.
+func ListLessons(ctx context.Context) error {
+	cfg, err := config.Load(ctx, ".")
+	if err != nil {
+		return err
+	}
+	lessons, err := loadLessons(cfg)
+	if err != nil {
+		return err
+	}
+	for _, l := range lessons {
+		fmt.Printf("%-20s %s (%s", l.ID, l.Title, countNoun(len(l.Steps), "step"))
+		if l.Level != "" {
+			fmt.Printf(", %s", l.Level)
+		}
+		fmt.Print(")")
+		if l.Source != "builtin" {
+			fmt.Printf(" — %s", l.Source)
+		}
+		fmt.Println()
+		if l.Summary != "" {
+			fmt.Println(strings.Repeat(" ", 21) + l.Summary)
+		}
+	}
+	return nil
+}
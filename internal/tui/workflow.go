@@ -0,0 +1,111 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package tui
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/config"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/failure"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/git"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/workflow"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ---------------- Model ----------------
+
+// workflowModel shows the steps of a workflow with their state, the explanation
+// of the running step and the latest output.
+type workflowModel struct {
+	runModel
+	w workflow.Workflow
+}
+
+func newWorkflowModel(ctx context.Context, w workflow.Workflow, vars map[string]string) workflowModel {
+	return workflowModel{runModel: newRunModel(ctx, w.Name, w, vars), w: w}
+}
+
+func (m workflowModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.runModel, cmd = m.update(msg)
+	return m, cmd
+}
+
+func (m workflowModel) View() string {
+	if m.help.open {
+		return m.helpView()
+	}
+	s := m.header.View()
+	if m.w.Explain != "" {
+		s += theme.muted().Render(strings.TrimSpace(m.w.Explain)) + "\n"
+	}
+	s += "\n" + m.steps.View(m.spinner.View()) + "\n"
+	if m.err != nil || m.done {
+		s += m.status("", m.w.Name+" complete.") + "\n"
+	}
+	s += m.log.View()
+	return s + m.footer()
+}
+
+// ---------------- Public Entry ----------------
+
+// WorkflowVars are the variables every workflow of .gitmate.yml can use, next
+// to its own arguments.
+var WorkflowVars = []string{"trunk", "branch", "upstream", "remote"}
+
+// workflowVars returns the values of WorkflowVars for the current repository.
+func workflowVars(ctx context.Context, cfg config.Config) map[string]string {
+	branch, _ := git.CurrentBranch(ctx, ".")
+	upstream, _ := git.Upstream(ctx, ".")
+	return map[string]string{
+		"trunk":    cfg.Trunk,
+		"branch":   branch,
+		"upstream": upstream,
+		"remote":   cfg.Push.Remote,
+	}
+}
+
+// RunWorkflowTUI runs the workflow called name from .gitmate.yml with its
+// positional arguments.
+func RunWorkflowTUI(ctx context.Context, name string, args []string) error {
+	return runApp(ctx, func(ctx context.Context) error { return workflowFlow(ctx, name, args) })
+}
+
+// workflowFlow runs the workflow, offering to roll back when it fails.
+func workflowFlow(ctx context.Context, name string, args []string) error {
+	cfg, err := config.Load(ctx, ".")
+	if err != nil {
+		return err
+	}
+	w, ok := cfg.Workflows[name]
+	if !ok {
+		return failure.Errorf(failure.Usage, "no workflow named %s in %s", name, config.FileName)
+	}
+	w.Name = name
+	if len(args) != len(w.Args) {
+		return failure.Errorf(failure.Usage, "%s takes %d arguments (%s), got %d",
+			name, len(w.Args), strings.Join(w.Args, ", "), len(args))
+	}
+	vars := workflowVars(ctx, cfg)
+	for i, a := range w.Args {
+		vars[a] = args[i]
+	}
+	if err := w.Validate(vars); err != nil {
+		return failure.New(failure.Usage, err)
+	}
+
+	output.data(map[string]any{"workflow": name, "vars": vars})
+	snap := snapshot(ctx)
+	final, err := runProgram(ctx, newWorkflowModel(ctx, w, vars), func(ctx context.Context, p sender) {
+		runWorkflow(ctx, p, w, vars)
+	})
+	if err != nil {
+		return rollbackAfter(ctx, snap, final, err)
+	}
+	if m, ok := final.(workflowModel); ok {
+		return rollbackAfter(ctx, snap, m, finished(m.err))
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/failure"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/git"
+)
+
+// EventKind says what happened in an Event.
+type EventKind int
+
+const (
+	StepStarted    EventKind = iota // a step starts after its preconditions held
+	StepSkipped                     // a step's If condition did not hold; Err says why
+	StepRetrying                    // a step failed with Err and runs again as Attempt
+	StepDone                        // a step succeeded
+	StepFailed                      // a step failed with Err; the workflow stops
+	CommandStarted                  // a command starts: Command
+	CommandOutput                   // the command printed Line
+	CommandDone                     // the command ended after Duration with ExitCode
+)
+
+// Event reports the progress of a running workflow.
+type Event struct {
+	Kind     EventKind
+	Step     int           // index of the step in Workflow.Steps
+	Name     string        // name of the step
+	Command  []string      // program and arguments, e.g. ["git", "fetch", "--all"]
+	Line     string        // CommandOutput: one line of output
+	Stderr   bool          // CommandOutput: the line came from stderr
+	Duration time.Duration // CommandDone
+	ExitCode int           // CommandDone: -1 when the command didn't start or was killed
+	Attempt  int           // StepRetrying: the attempt about to run, from 2
+	Err      error
+}
+
+// Runner executes workflows in a repository.
+type Runner struct {
+	Dir  string            // repository directory; "" for the current directory
+	Vars map[string]string // values of the {name} placeholders
+	Emit func(Event)       // receives progress events; may be nil
+}
+
+func (r *Runner) emit(e Event) {
+	if r.Emit != nil {
+		r.Emit(e)
+	}
+}
+
+// Run executes the steps of w in order. When a step fails, Run stops and
+// returns the step's error with its failure kind. Undoing the steps that
+// completed is up to the caller, which can ask first; see Rollbacks.
+func (r *Runner) Run(ctx context.Context, w Workflow) error {
+	for i, s := range w.Steps {
+		if s.If != nil {
+			if err := s.If.Check(ctx, r.Dir, r.Vars); err != nil {
+				r.emit(Event{Kind: StepSkipped, Step: i, Name: s.Name, Err: err})
+				continue
+			}
+		}
+		r.emit(Event{Kind: StepStarted, Step: i, Name: s.Name})
+		var err error
+		if s.Require != nil {
+			err = s.Require.Check(ctx, r.Dir, r.Vars)
+		}
+		if err == nil {
+			err = r.attempt(ctx, i, s)
+		}
+		if err != nil {
+			if s.Name != "" {
+				err = fmt.Errorf("%s: %w", s.Name, err)
+			}
+			r.emit(Event{Kind: StepFailed, Step: i, Name: s.Name, Err: err})
+			return err
+		}
+		r.emit(Event{Kind: StepDone, Step: i, Name: s.Name})
+	}
+	return nil
+}
+
+// attempt runs a step, retrying it as often as it allows.
+func (r *Runner) attempt(ctx context.Context, i int, s Step) error {
+	for n := 1; ; n++ {
+		err := r.runStep(ctx, i, s)
+		if err == nil || n > s.Retries || ctx.Err() != nil ||
+			git.OperationInProgress(ctx, r.Dir) != "" {
+			return err
+		}
+		r.emit(Event{Kind: StepRetrying, Step: i, Name: s.Name, Attempt: n + 1, Err: err})
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(time.Duration(n) * time.Second):
+		}
+	}
+}
+
+func (r *Runner) runStep(ctx context.Context, i int, s Step) error {
+	if s.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.Timeout)
+		defer cancel()
+	}
+	switch {
+	case s.Do != nil:
+		log := func(line string) {
+			r.emit(Event{Kind: CommandOutput, Step: i, Name: s.Name, Line: line})
+		}
+		return s.Do(ctx, log)
+	case s.Run != "":
+		err := r.command(ctx, i, s.Name, []string{"sh", "-c", expandShell(s.Run, r.Vars)})
+		return r.classify(ctx, nil, err)
+	default:
+		args := expandAll(s.Git, r.Vars)
+		ctx, cancel := git.WithTimeout(ctx, args)
+		defer cancel()
+		err := r.command(ctx, i, s.Name, append([]string{"git"}, args...))
+		return r.classify(ctx, args, err)
+	}
+}
+
+// classify gives a failed command its failure kind. Commands stopped by ctx
+// are Interrupted or Timeout; for git, anything that leaves a rebase or merge
+// stopped half-way is a Conflict, and commands that talk to a remote fail as
+// Remote. args is nil for run commands.
+func (r *Runner) classify(ctx context.Context, args []string, err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		if args == nil {
+			return failure.Errorf(failure.Timeout, "timed out: %w", err)
+		}
+		return failure.Errorf(failure.Timeout, "git %s timed out (raise timeouts.git.%s in the config or pass --timeout): %w",
+			git.Subcommand(args), git.Subcommand(args), err)
+	case errors.Is(ctx.Err(), context.Canceled):
+		return failure.Errorf(failure.Interrupted, "interrupted: %w", err)
+	case args == nil:
+		return failure.New(failure.Unknown, err)
+	}
+	if op := git.OperationInProgress(ctx, r.Dir); op != "" {
+		return failure.Errorf(failure.Conflict, "%s stopped on conflicts; resolve them and continue, or abort it (%w)", op, err)
+	}
+	switch git.Subcommand(args) {
+	case "fetch", "pull", "push", "ls-remote":
+		return failure.New(failure.Remote, err)
+	}
+	return failure.New(failure.Git, err)
+}
+
+// command runs argv in the repository, emitting its output line by line.
+func (r *Runner) command(ctx context.Context, i int, name string, argv []string) error {
+	r.emit(Event{Kind: CommandStarted, Step: i, Name: name, Command: argv})
+	start := time.Now()
+	err := r.stream(ctx, argv, func(line string, stderr bool) {
+		r.emit(Event{Kind: CommandOutput, Step: i, Name: name, Line: line, Stderr: stderr})
+	})
+	r.emit(Event{Kind: CommandDone, Step: i, Name: name, Command: argv,
+		Duration: time.Since(start), ExitCode: ExitCode(err)})
+	return err
+}
+
+func (r *Runner) stream(ctx context.Context, argv []string, onLine func(string, bool)) error {
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	cmd.Dir = r.Dir
+	git.Graceful(cmd)
+	// writers rather than pipes, so Wait stops reading once the command is
+	// killed even when a child it started still holds the output open
+	var mu sync.Mutex
+	stdout := &lineWriter{mu: &mu, emit: func(line string) { onLine(line, false) }}
+	stderr := &lineWriter{mu: &mu, emit: func(line string) { onLine(line, true) }}
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("start %s: %w", argv[0], err)
+	}
+	err := cmd.Wait()
+	stdout.flush()
+	stderr.flush()
+	if err != nil {
+		return fmt.Errorf("%s failed: %w", argv[0], err)
+	}
+	return nil
+}
+
+// lineWriter calls emit for every complete line written to it, ended by \n,
+// \r\n or a lone \r.
+type lineWriter struct {
+	mu   *sync.Mutex // shared by the writers of one command
+	buf  []byte
+	cr   bool // the last write ended with \r
+	emit func(string)
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	n := len(p)
+	if w.cr && len(p) > 0 && p[0] == '\n' {
+		// the \n of a \r\n whose \r ended the last write
+		p = p[1:]
+	}
+	w.cr = false
+	w.buf = append(w.buf, p...)
+	for {
+		// git --progress rewrites its line with carriage returns; every
+		// rewrite counts as a line
+		i := bytes.IndexAny(w.buf, "\r\n")
+		if i < 0 {
+			break
+		}
+		w.emit(string(w.buf[:i]))
+		if w.buf[i] == '\r' {
+			switch {
+			case i+1 == len(w.buf):
+				w.cr = true
+			case w.buf[i+1] == '\n':
+				i++
+			}
+		}
+		w.buf = w.buf[i+1:]
+	}
+	return n, nil
+}
+
+// flush emits what is left after the last newline.
+func (w *lineWriter) flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.buf) > 0 {
+		w.emit(string(w.buf))
+		w.buf = nil
+	}
+}
+
+// ExitCode extracts the exit status from a command error: 0 for nil and -1
+// when the command didn't run to completion.
+func ExitCode(err error) int {
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &exitErr):
+		return exitErr.ExitCode()
+	}
+	return -1
+}
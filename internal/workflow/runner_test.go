@@ -0,0 +1,116 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package workflow
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/failure"
+)
+
+func TestLineWriter(t *testing.T) {
+	tests := []struct {
+		name   string
+		writes []string
+		lines  []string
+	}{
+		{"lines", []string{"a\nb\n"}, []string{"a", "b"}},
+		{"split line", []string{"hel", "lo\nwor", "ld\n"}, []string{"hello", "world"}},
+		{"crlf", []string{"a\r\nb\r\n"}, []string{"a", "b"}},
+		{"crlf across writes", []string{"a\r", "\nb\n"}, []string{"a", "b"}},
+		{"progress", []string{"10%\r50%\r100%, done.\n"}, []string{"10%", "50%", "100%, done."}},
+		{"empty lines", []string{"a\n\nb\n"}, []string{"a", "", "b"}},
+		{"no final newline", []string{"a\nrest"}, []string{"a", "rest"}},
+		{"nothing", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lines []string
+			w := &lineWriter{mu: &sync.Mutex{}, emit: func(l string) { lines = append(lines, l) }}
+			for _, s := range tt.writes {
+				if n, err := w.Write([]byte(s)); n != len(s) || err != nil {
+					t.Fatalf("Write(%q) = %d, %v", s, n, err)
+				}
+			}
+			w.flush()
+			if !reflect.DeepEqual(lines, tt.lines) {
+				t.Errorf("lines = %q, want %q", lines, tt.lines)
+			}
+		})
+	}
+}
+
+func TestClassify(t *testing.T) {
+	failed := errors.New("exit status 1")
+	expired, cancel := context.WithDeadline(context.Background(), time.Now())
+	defer cancel()
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		args     []string // nil for run commands
+		err      error
+		conflict bool // a merge stopped on conflicts
+		kind     failure.Kind
+	}{
+		{name: "success", ctx: context.Background(), args: []string{"status"}},
+		{name: "run fails", ctx: context.Background(), err: failed, kind: failure.Unknown},
+		{name: "run times out", ctx: expired, err: failed, kind: failure.Timeout},
+		{name: "git times out", ctx: expired, args: []string{"fetch"}, err: failed, kind: failure.Timeout},
+		{name: "interrupted", ctx: canceled, args: []string{"fetch"}, err: failed, kind: failure.Interrupted},
+		{name: "git fails", ctx: context.Background(), args: []string{"commit"}, err: failed, kind: failure.Git},
+		{name: "fetch fails", ctx: context.Background(), args: []string{"fetch", "origin"}, err: failed, kind: failure.Remote},
+		{name: "push fails", ctx: context.Background(), args: []string{"-c", "push.default=current", "push"}, err: failed, kind: failure.Remote},
+		{name: "conflict", ctx: context.Background(), args: []string{"merge", "other"}, err: failed, conflict: true, kind: failure.Conflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, git := testRepo(t)
+			if tt.conflict {
+				git("switch", "-q", "-c", "other")
+				write(t, dir, "README.md", "theirs\n")
+				git("commit", "-q", "-am", "theirs")
+				git("switch", "-q", "main")
+				write(t, dir, "README.md", "ours\n")
+				git("commit", "-q", "-am", "ours")
+				r := &Runner{Dir: dir}
+				if err := r.command(context.Background(), 0, "merge", []string{"git", "merge", "other"}); err == nil {
+					t.Fatal("merge succeeded without conflicts")
+				}
+			}
+			r := &Runner{Dir: dir}
+			err := r.classify(tt.ctx, tt.args, tt.err)
+			if got := failure.KindOf(err); got != tt.kind {
+				t.Errorf("classify = %v (kind %d), want kind %d", err, got, tt.kind)
+			}
+			if tt.err != nil && !errors.Is(err, tt.err) {
+				t.Errorf("classify = %v, want it to wrap %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestRunQuotesVariables(t *testing.T) {
+	dir, _ := testRepo(t)
+	r := &Runner{Dir: dir, Vars: map[string]string{"name": "x; touch pwned"}}
+	w := Workflow{Name: "t", Steps: []Step{{Name: "Write", Run: "printf '%s' {name} > out"}}}
+	if err := r.Run(context.Background(), w); err != nil {
+		t.Fatal(err)
+	}
+	if out, _ := os.ReadFile(filepath.Join(dir, "out")); string(out) != "x; touch pwned" {
+		t.Errorf("out = %q, want the variable as one word", out)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "pwned")); err == nil {
+		t.Error("the variable ran as a command")
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package workflow
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/git"
+)
+
+// Snapshot is where a repository stood before a workflow ran.
+type Snapshot struct {
+	Branch string // checked out branch; empty when HEAD was detached
+	Head   string // commit HEAD pointed at
+	Stash  string // commit at refs/stash; empty without stashes
+	Dirty  bool   // the working tree had uncommitted changes
+}
+
+// Capture records the current state of the repository in dir. Take it before
+// anything touches the working tree, uncommitted changes included.
+func Capture(ctx context.Context, dir string) (Snapshot, error) {
+	head, err := git.RevParse(ctx, dir, "HEAD")
+	if err != nil {
+		return Snapshot{}, err
+	}
+	s := Snapshot{Head: head}
+	if branch, err := git.CurrentBranch(ctx, dir); err == nil && branch != "HEAD" {
+		s.Branch = branch
+	}
+	s.Stash, _ = git.RevParse(ctx, dir, "refs/stash")
+	if s.Dirty, err = git.IsDirty(ctx, dir); err != nil {
+		return Snapshot{}, err
+	}
+	return s, nil
+}
+
+// Undo is one action that takes the repository back towards a snapshot.
+type Undo struct {
+	What string   // what it does, e.g. "switch back to feature/login"
+	Git  []string // git arguments doing it
+}
+
+// Undo lists what it takes to bring the repository in dir back to s, in order:
+// abort a stopped rebase or merge, return to the starting branch, reset it to
+// where it was and pop the stash the workflow made of uncommitted changes.
+// It is empty when the repository is where it started.
+func (s Snapshot) Undo(ctx context.Context, dir string) []Undo {
+	var undo []Undo
+
+	current, _ := git.CurrentBranch(ctx, dir)
+	if op := git.OperationInProgress(ctx, dir); op != "" {
+		undo = append(undo, Undo{What: "abort the stopped " + op, Git: []string{op, "--abort"}})
+		if op == "rebase" {
+			// aborting returns to the branch being rebased
+			current = rebaseBranch(ctx, dir, current)
+		}
+	}
+
+	switch {
+	case s.Branch == "":
+		if head, _ := git.RevParse(ctx, dir, "HEAD"); head != s.Head {
+			undo = append(undo, Undo{What: "check out " + short(s.Head) + " again", Git: []string{"checkout", s.Head}})
+		}
+	case !git.RefExists(ctx, dir, "refs/heads/"+s.Branch):
+		undo = append(undo, Undo{What: "recreate " + s.Branch + " at " + short(s.Head), Git: []string{"checkout", "-b", s.Branch, s.Head}})
+	default:
+		if current != s.Branch {
+			undo = append(undo, Undo{What: "switch back to " + s.Branch, Git: []string{"checkout", s.Branch}})
+		}
+		if tip, _ := git.RevParse(ctx, dir, "refs/heads/"+s.Branch); tip != s.Head {
+			parent, _ := git.RevParse(ctx, dir, tip+"^")
+			if s.Dirty && parent == s.Head {
+				// the workflow committed the uncommitted changes; turn them back into changes
+				undo = append(undo, Undo{What: "uncommit the changes saved on " + s.Branch, Git: []string{"reset", "--mixed", s.Head}})
+			} else {
+				undo = append(undo, Undo{What: "reset " + s.Branch + " to " + short(s.Head), Git: []string{"reset", "--keep", s.Head}})
+			}
+		}
+	}
+
+	if s.Dirty {
+		top, _ := git.RevParse(ctx, dir, "refs/stash")
+		below, _ := git.RevParse(ctx, dir, "stash@{1}")
+		if top != "" && top != s.Stash && below == s.Stash {
+			undo = append(undo, Undo{What: "restore the uncommitted changes from the stash", Git: []string{"stash", "pop", "--index"}})
+		}
+	}
+	return undo
+}
+
+// Rollbacks lists the Rollback commands of the steps in done, indexes into
+// steps, newest first.
+func Rollbacks(steps []Step, done []int, vars map[string]string) []Undo {
+	var undo []Undo
+	for j := len(done) - 1; j >= 0; j-- {
+		s := steps[done[j]]
+		if len(s.Rollback) == 0 {
+			continue
+		}
+		args := expandAll(s.Rollback, vars)
+		what := "undo " + s.Name
+		if s.Name == "" {
+			what = "run git " + strings.Join(args, " ")
+		}
+		undo = append(undo, Undo{What: what, Git: args})
+	}
+	return undo
+}
+
+// Restore runs undo in order and returns what was done. It stops at the first
+// action that fails.
+func (r *Runner) Restore(ctx context.Context, undo []Undo) ([]string, error) {
+	var done []string
+	for _, u := range undo {
+		if err := r.command(ctx, -1, "Roll back", append([]string{"git"}, u.Git...)); err != nil {
+			return done, err
+		}
+		done = append(done, u.What)
+	}
+	return done, nil
+}
+
+// rebaseBranch returns the branch a stopped rebase works on, or fallback.
+func rebaseBranch(ctx context.Context, dir, fallback string) string {
+	gitDir, err := git.GitDir(ctx, dir)
+	if err != nil {
+		return fallback
+	}
+	for _, d := range []string{"rebase-merge", "rebase-apply"} {
+		if b, err := os.ReadFile(filepath.Join(gitDir, d, "head-name")); err == nil {
+			return strings.TrimPrefix(strings.TrimSpace(string(b)), "refs/heads/")
+		}
+	}
+	return fallback
+}
+
+func short(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
@@ -0,0 +1,122 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package workflow
+
+import (
+	"context"
+	"os/exec"
+	"reflect"
+	"testing"
+)
+
+func TestSnapshotUndo(t *testing.T) {
+	tests := []struct {
+		name   string
+		before func(t *testing.T, dir string, git func(...string) string) // sets up the start
+		after  func(t *testing.T, dir string, git func(...string) string) // what the workflow did
+		undo   [][]string                                                 // {head} is the starting commit
+	}{
+		{
+			name:  "nothing changed",
+			after: func(t *testing.T, dir string, git func(...string) string) {},
+		},
+		{
+			name: "switched branch",
+			after: func(t *testing.T, dir string, git func(...string) string) {
+				git("switch", "-q", "-c", "feature/login")
+			},
+			undo: [][]string{{"checkout", "main"}},
+		},
+		{
+			name: "committed",
+			after: func(t *testing.T, dir string, git func(...string) string) {
+				git("commit", "-q", "--allow-empty", "-m", "more")
+			},
+			undo: [][]string{{"reset", "--keep", "{head}"}},
+		},
+		{
+			name: "committed the changes",
+			before: func(t *testing.T, dir string, git func(...string) string) {
+				write(t, dir, "README.md", "changed\n")
+			},
+			after: func(t *testing.T, dir string, git func(...string) string) {
+				git("commit", "-q", "-am", "wip")
+			},
+			undo: [][]string{{"reset", "--mixed", "{head}"}},
+		},
+		{
+			name: "stashed the changes",
+			before: func(t *testing.T, dir string, git func(...string) string) {
+				write(t, dir, "README.md", "changed\n")
+			},
+			after: func(t *testing.T, dir string, git func(...string) string) {
+				git("stash", "-q")
+				git("switch", "-q", "-c", "feature/login")
+			},
+			undo: [][]string{{"checkout", "main"}, {"stash", "pop", "--index"}},
+		},
+		{
+			name: "deleted the branch",
+			after: func(t *testing.T, dir string, git func(...string) string) {
+				git("switch", "-q", "-c", "other")
+				git("branch", "-q", "-D", "main")
+			},
+			undo: [][]string{{"checkout", "-b", "main", "{head}"}},
+		},
+		{
+			name: "detached",
+			before: func(t *testing.T, dir string, git func(...string) string) {
+				git("switch", "-q", "--detach")
+			},
+			after: func(t *testing.T, dir string, git func(...string) string) {
+				git("commit", "-q", "--allow-empty", "-m", "more")
+			},
+			undo: [][]string{{"checkout", "{head}"}},
+		},
+		{
+			name: "rebase stopped",
+			before: func(t *testing.T, dir string, git func(...string) string) {
+				git("switch", "-q", "-c", "feature/login")
+				write(t, dir, "README.md", "ours\n")
+				git("commit", "-q", "-am", "ours")
+				git("switch", "-q", "main")
+				write(t, dir, "README.md", "theirs\n")
+				git("commit", "-q", "-am", "theirs")
+				git("switch", "-q", "feature/login")
+			},
+			after: func(t *testing.T, dir string, git func(...string) string) {
+				if exec.Command("git", "-C", dir, "rebase", "main").Run() == nil {
+					t.Fatal("rebase succeeded without conflicts")
+				}
+			},
+			undo: [][]string{{"rebase", "--abort"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			dir, git := testRepo(t)
+			if tt.before != nil {
+				tt.before(t, dir, git)
+			}
+			snap, err := Capture(ctx, dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.after(t, dir, git)
+
+			var got [][]string
+			for _, u := range snap.Undo(ctx, dir) {
+				got = append(got, u.Git)
+			}
+			var want [][]string
+			for _, args := range tt.undo {
+				want = append(want, expandAll(args, map[string]string{"head": snap.Head}))
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Undo = %q, want %q", got, want)
+			}
+		})
+	}
+}
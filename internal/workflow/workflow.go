@@ -0,0 +1,218 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+
+// Package workflow runs git workflows declared as ordered, named steps. Each
+// step can carry preconditions, a command, an explanation shown while it runs
+// and a compensating rollback command that undoes it when a later step fails.
+//
+// GitMate's own commands are built from workflows, and teams can declare more
+// under `workflows:` in .gitmate.yml:
+//
+//	workflows:
+//	  hotfix:
+//	    short: Start a hotfix branch from the latest release
+//	    args: [name]
+//	    steps:
+//	      - name: Fetch releases
+//	        git: [fetch, origin, --tags]
+//	        retries: 2
+//	      - name: Create the hotfix branch
+//	        explain: Hotfixes start from the last release, not from the trunk.
+//	        require: {clean: true}
+//	        git: [switch, -c, "hotfix/{name}", "origin/release"]
+//	        rollback: [switch, "{branch}"]
+//	      - name: Run the tests
+//	        run: make test
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/failure"
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/git"
+)
+
+// Workflow is an ordered list of steps run one after another.
+type Workflow struct {
+	Name    string   `yaml:"-"`       // command name; the key under `workflows:`
+	Short   string   `yaml:"short"`   // one-line description shown in --help
+	Explain string   `yaml:"explain"` // longer description shown in --help and the TUI
+	Args    []string `yaml:"args"`    // names of the positional arguments, usable as {name}
+	Steps   []Step   `yaml:"steps"`
+}
+
+// Step is one unit of a workflow. Exactly one of Git, Run or Do says what it does.
+type Step struct {
+	Name    string     `yaml:"name"`
+	Explain string     `yaml:"explain"` // why the step runs, shown while it does
+	If      *Condition `yaml:"if"`      // the step is skipped unless this holds
+	Require *Condition `yaml:"require"` // the workflow fails unless this holds
+	Git     []string   `yaml:"git"`     // git arguments; {var} placeholders are expanded
+	Run     string     `yaml:"run"`     // shell command run with sh -c; {var} placeholders expand to quoted words
+	// Rollback are the git arguments that undo the step. When a later step
+	// fails they are offered, newest step first, with the rollback to where
+	// the workflow started.
+	Rollback []string `yaml:"rollback"`
+	Retries  int      `yaml:"retries"` // extra attempts when the command fails, e.g. for flaky networks
+	// Timeout bounds the command; git commands default to the configured git
+	// timeouts and run commands to no limit.
+	Timeout time.Duration `yaml:"timeout"`
+
+	// Do implements steps that are Go code rather than a command. log prints a
+	// line of output; lines starting with "[warn] " are warnings.
+	Do func(ctx context.Context, log func(string)) error `yaml:"-"`
+}
+
+// Condition is a check on the state of the repository. Every field that is set
+// has to hold.
+type Condition struct {
+	Clean    *bool  `yaml:"clean"`    // the working tree has (no) uncommitted changes
+	Branch   string `yaml:"branch"`   // the current branch matches this regular expression
+	Upstream *bool  `yaml:"upstream"` // the current branch has (no) upstream
+	Exists   string `yaml:"exists"`   // this ref resolves, e.g. origin/{trunk}
+}
+
+// Check returns nil when c holds in dir, or an error describing what doesn't.
+// Unclean working trees fail with failure.Dirty, everything else with failure.Usage.
+func (c Condition) Check(ctx context.Context, dir string, vars map[string]string) error {
+	if c.Clean != nil {
+		dirty, err := git.IsDirty(ctx, dir)
+		if err != nil {
+			return failure.New(failure.Git, err)
+		}
+		if dirty == *c.Clean {
+			if dirty {
+				return failure.Errorf(failure.Dirty, "working tree has uncommitted changes")
+			}
+			return failure.Errorf(failure.Usage, "working tree has no uncommitted changes")
+		}
+	}
+	if c.Branch != "" {
+		// values match literally, so a branch named fix+1 doesn't turn into a pattern
+		re, err := regexp.Compile(expandWith(c.Branch, vars, regexp.QuoteMeta))
+		if err != nil {
+			return failure.Errorf(failure.Usage, "invalid branch pattern: %w", err)
+		}
+		branch, err := git.CurrentBranch(ctx, dir)
+		if err != nil {
+			return failure.New(failure.Git, err)
+		}
+		if !re.MatchString(branch) {
+			return failure.Errorf(failure.Usage, "branch %s does not match %s", branch, re)
+		}
+	}
+	if c.Upstream != nil {
+		_, err := git.Upstream(ctx, dir)
+		if has := err == nil; has != *c.Upstream {
+			if has {
+				return failure.Errorf(failure.Usage, "branch already has an upstream")
+			}
+			return failure.Errorf(failure.Usage, "branch has no upstream")
+		}
+	}
+	if c.Exists != "" {
+		if ref := Expand(c.Exists, vars); !git.RefExists(ctx, dir, ref) {
+			return failure.Errorf(failure.Usage, "%s does not exist", ref)
+		}
+	}
+	return nil
+}
+
+var placeholderRe = regexp.MustCompile(`\{([A-Za-z0-9_-]+)\}`)
+
+// Expand replaces the {name} placeholders in s that name a variable in vars.
+// Other braces are left alone.
+func Expand(s string, vars map[string]string) string {
+	return expandWith(s, vars, nil)
+}
+
+// expandShell is Expand for a shell command: every value is single-quoted, so
+// it stays one word however it is spelled and is never run itself. Placeholders
+// must therefore not be quoted again in the command.
+func expandShell(s string, vars map[string]string) string {
+	return expandWith(s, vars, shellQuote)
+}
+
+// expandWith replaces the placeholders of s by their values, passed through
+// quote unless it is nil.
+func expandWith(s string, vars map[string]string, quote func(string) string) string {
+	return placeholderRe.ReplaceAllStringFunc(s, func(m string) string {
+		v, ok := vars[m[1:len(m)-1]]
+		switch {
+		case !ok:
+			return m
+		case quote != nil:
+			return quote(v)
+		}
+		return v
+	})
+}
+
+// shellQuote quotes s as a single word for sh.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// Validate checks that every step says what it does and, when vars is not nil,
+// that all placeholders name one of its variables.
+func (w Workflow) Validate(vars map[string]string) error {
+	if len(w.Steps) == 0 {
+		return fmt.Errorf("workflow %s has no steps", w.Name)
+	}
+	for i, s := range w.Steps {
+		what := 0
+		for _, set := range []bool{len(s.Git) > 0, s.Run != "", s.Do != nil} {
+			if set {
+				what++
+			}
+		}
+		label := s.Name
+		if label == "" {
+			label = fmt.Sprintf("step %d", i+1)
+		}
+		if what != 1 {
+			return fmt.Errorf("workflow %s: %s needs exactly one of git or run", w.Name, label)
+		}
+		if vars == nil {
+			continue
+		}
+		fields := append(append([]string{s.Run}, s.Git...), s.Rollback...)
+		for _, c := range []*Condition{s.If, s.Require} {
+			if c != nil {
+				fields = append(fields, c.Branch, c.Exists)
+			}
+		}
+		for _, f := range fields {
+			for _, m := range placeholderRe.FindAllStringSubmatch(f, -1) {
+				if _, ok := vars[m[1]]; !ok {
+					return fmt.Errorf("workflow %s: %s uses unknown variable {%s}", w.Name, label, m[1])
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Describe returns the command line of a step for display, e.g. "git fetch --all".
+func (s Step) Describe(vars map[string]string) string {
+	switch {
+	case len(s.Git) > 0:
+		return "git " + strings.Join(expandAll(s.Git, vars), " ")
+	case s.Run != "":
+		return expandShell(s.Run, vars)
+	}
+	return ""
+}
+
+func expandAll(args []string, vars map[string]string) []string {
+	res := make([]string, len(args))
+	for i, a := range args {
+		res[i] = Expand(a, vars)
+	}
+	return res
+}
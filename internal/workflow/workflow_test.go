@@ -0,0 +1,147 @@
+/*
+Copyright © 2025 Bernard Katamanso <bernard@orctatech.com>
+*/
+package workflow
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Orctatech-Engineering-Team/GitMate/internal/failure"
+)
+
+// testRepo makes a repository on main with one commit, and returns it with a
+// function running git in it.
+func testRepo(t *testing.T) (dir string, git func(args ...string) string) {
+	t.Helper()
+	for _, k := range []string{"GIT_AUTHOR", "GIT_COMMITTER"} {
+		t.Setenv(k+"_NAME", "Ama")
+		t.Setenv(k+"_EMAIL", "ama@example.com")
+	}
+	dir = t.TempDir()
+	git = func(args ...string) string {
+		t.Helper()
+		out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	git("init", "-q", "-b", "main")
+	write(t, dir, "README.md", "hello\n")
+	git("add", "README.md")
+	git("commit", "-q", "-m", "init")
+	return dir, git
+}
+
+func write(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExpand(t *testing.T) {
+	vars := map[string]string{"name": "login", "trunk": "main", "x-y": "z"}
+	tests := []struct {
+		in, want string
+	}{
+		{"feature/{name}", "feature/login"},
+		{"{trunk}..{name}", "main..login"},
+		{"{x-y}", "z"},
+		{"{unknown}", "{unknown}"},
+		{"HEAD@{1}", "HEAD@{1}"},
+		{"{ name }", "{ name }"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := Expand(tt.in, vars); got != tt.want {
+			t.Errorf("Expand(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestExpandShell(t *testing.T) {
+	values := []string{
+		"login",
+		"two words",
+		"it's",
+		"$(touch pwned)",
+		"`touch pwned`",
+		"x; touch pwned",
+		`back\slash "and" quotes`,
+		"",
+	}
+	dir := t.TempDir()
+	for _, v := range values {
+		cmd := expandShell("printf '%s|' {v} {missing}", map[string]string{"v": v})
+		c := exec.Command("sh", "-c", cmd)
+		c.Dir = dir
+		out, err := c.Output()
+		if err != nil {
+			t.Fatalf("sh -c %q: %v", cmd, err)
+		}
+		if want := v + "|{missing}|"; string(out) != want {
+			t.Errorf("value %q printed as %q, want %q", v, out, want)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "pwned")); err == nil {
+		t.Error("a value ran as a command")
+	}
+}
+
+func TestConditionCheck(t *testing.T) {
+	yes, no := true, false
+	tests := []struct {
+		name   string
+		dirty  bool
+		branch string // checked out before the check
+		cond   Condition
+		vars   map[string]string
+		kind   failure.Kind // 0 when the condition holds
+	}{
+		{name: "clean", cond: Condition{Clean: &yes}},
+		{name: "clean but dirty", dirty: true, cond: Condition{Clean: &yes}, kind: failure.Dirty},
+		{name: "dirty", dirty: true, cond: Condition{Clean: &no}},
+		{name: "dirty but clean", cond: Condition{Clean: &no}, kind: failure.Usage},
+		{name: "branch", branch: "feature/login", cond: Condition{Branch: "^feature/"}},
+		{name: "other branch", cond: Condition{Branch: "^feature/"}, kind: failure.Usage},
+		{
+			name: "branch variable", branch: "fix/a+b",
+			cond: Condition{Branch: "^fix/{name}$"}, vars: map[string]string{"name": "a+b"},
+		},
+		{
+			name: "variable matches literally", branch: "fix/axb",
+			cond: Condition{Branch: "^fix/{name}$"}, vars: map[string]string{"name": "a.b"}, kind: failure.Usage,
+		},
+		{
+			name: "variable is no pattern", branch: "fix/a",
+			cond: Condition{Branch: "^fix/{name}$"}, vars: map[string]string{"name": "("}, kind: failure.Usage,
+		},
+		{name: "invalid pattern", cond: Condition{Branch: "("}, kind: failure.Usage},
+		{name: "no upstream", cond: Condition{Upstream: &no}},
+		{name: "upstream missing", cond: Condition{Upstream: &yes}, kind: failure.Usage},
+		{name: "exists", cond: Condition{Exists: "refs/heads/{trunk}"}, vars: map[string]string{"trunk": "main"}},
+		{name: "does not exist", cond: Condition{Exists: "origin/main"}, kind: failure.Usage},
+		{name: "all of them", dirty: true, cond: Condition{Clean: &yes, Branch: "^main$"}, kind: failure.Dirty},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, git := testRepo(t)
+			if tt.branch != "" {
+				git("switch", "-q", "-c", tt.branch)
+			}
+			if tt.dirty {
+				write(t, dir, "README.md", "changed\n")
+			}
+			err := tt.cond.Check(context.Background(), dir, tt.vars)
+			if got := failure.KindOf(err); got != tt.kind {
+				t.Errorf("Check = %v (kind %d), want kind %d", err, got, tt.kind)
+			}
+		})
+	}
+}